@@ -11,7 +11,8 @@ variable "solidfire_cluster" {
 }
 
 variable "solidfire_api_version" {
-  type = "string"
+  type    = "string"
+  default = ""
 }
 
 variable "total_size" {
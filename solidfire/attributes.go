@@ -0,0 +1,146 @@
+package solidfire
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// attributesSchemaVersion is the schema version of resources that store Element attributes as a map
+const attributesSchemaVersion = 1
+
+// attributesSchema returns the schema of the attributes argument, a map of string attributes
+func attributesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeMap,
+		Optional:      true,
+		Elem:          &schema.Schema{Type: schema.TypeString},
+		ConflictsWith: []string{"attributes_json"},
+	}
+}
+
+// attributesJSONSchema returns the schema of the attributes_json argument, which holds attributes with
+// nested or non-string values as a JSON object
+func attributesJSONSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateFunc:     validateJSONObject,
+		DiffSuppressFunc: suppressEquivalentJSON,
+		ConflictsWith:    []string{"attributes"},
+	}
+}
+
+// expandAttributes returns the attributes configured with attributes or attributes_json. The map is empty,
+// not nil, if neither is set.
+func expandAttributes(d *schema.ResourceData) (map[string]interface{}, error) {
+	if v, ok := d.GetOk("attributes_json"); ok {
+		var attributes map[string]interface{}
+		if err := json.Unmarshal([]byte(v.(string)), &attributes); err != nil {
+			return nil, fmt.Errorf("Invalid attributes_json: %v", err)
+		}
+		if attributes == nil {
+			attributes = map[string]interface{}{}
+		}
+		return attributes, nil
+	}
+
+	attributes := map[string]interface{}{}
+	for k, v := range d.Get("attributes").(map[string]interface{}) {
+		attributes[k] = v
+	}
+	return attributes, nil
+}
+
+// attributesChanged reports whether attributes or attributes_json are changed by the plan
+func attributesChanged(d *schema.ResourceData) bool {
+	return d.HasChange("attributes") || d.HasChange("attributes_json")
+}
+
+// setAttributes stores the attributes read from the cluster. They are stored in attributes if all values are
+// strings and attributes_json is not in use, and as JSON in attributes_json otherwise.
+func setAttributes(d *schema.ResourceData, v interface{}) error {
+	attributes, _ := v.(map[string]interface{})
+	if attributes == nil {
+		attributes = map[string]interface{}{}
+	}
+
+	if _, ok := d.GetOk("attributes_json"); !ok && stringValues(attributes) {
+		d.Set("attributes_json", "")
+		return d.Set("attributes", attributes)
+	}
+
+	data, err := json.Marshal(attributes)
+	if err != nil {
+		return fmt.Errorf("Unable to encode attributes: %v", err)
+	}
+	d.Set("attributes", map[string]interface{}{})
+	return d.Set("attributes_json", string(data))
+}
+
+func stringValues(m map[string]interface{}) bool {
+	for _, v := range m {
+		if _, ok := v.(string); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// validateJSONObject checks that a string argument is a JSON object
+func validateJSONObject(v interface{}, k string) (ws []string, errors []error) {
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(v.(string)), &object); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a JSON object: %v", k, err))
+	}
+	return
+}
+
+// suppressEquivalentJSON suppresses diffs between JSON documents that only differ in formatting or key order
+func suppressEquivalentJSON(k, old, new string, d *schema.ResourceData) bool {
+	var o, n interface{}
+	if err := json.Unmarshal([]byte(old), &o); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &n); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(o, n)
+}
+
+// migrateAttributesState migrates the state of resources with attributes to attributesSchemaVersion
+func migrateAttributesState(v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	switch v {
+	case 0:
+		log.Println("[INFO] Found SolidFire state v0; migrating to v1")
+		return migrateAttributesStateV0toV1(is)
+	default:
+		return is, fmt.Errorf("Unexpected schema version: %d", v)
+	}
+}
+
+// migrateAttributesStateV0toV1 drops the list of strings that version 0 stored in attributes. The list was
+// never sent to the cluster, so the attributes are read back from the cluster on the next refresh instead.
+func migrateAttributesStateV0toV1(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	if is == nil || is.Attributes == nil {
+		log.Println("[DEBUG] Empty SolidFire state; nothing to migrate.")
+		return is, nil
+	}
+
+	log.Printf("[DEBUG] SolidFire attributes before migration: %#v", is.Attributes)
+
+	for k := range is.Attributes {
+		if strings.HasPrefix(k, "attributes.") {
+			delete(is.Attributes, k)
+		}
+	}
+	is.Attributes["attributes.%"] = "0"
+
+	log.Printf("[DEBUG] SolidFire attributes after migration: %#v", is.Attributes)
+	return is, nil
+}
@@ -0,0 +1,84 @@
+package solidfire
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestMigrateAttributesStateV0toV1(t *testing.T) {
+	is := &terraform.InstanceState{
+		ID: "42",
+		Attributes: map[string]string{
+			"name":         "data",
+			"attributes.#": "2",
+			"attributes.0": "owner",
+			"attributes.1": "ops",
+		},
+	}
+
+	is, err := migrateAttributesState(0, is, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"name":         "data",
+		"attributes.%": "0",
+	}
+	if len(is.Attributes) != len(expected) {
+		t.Fatalf("Expected attributes %v, got %v", expected, is.Attributes)
+	}
+	for k, v := range expected {
+		if is.Attributes[k] != v {
+			t.Fatalf("Expected %v to be %q, got %q", k, v, is.Attributes[k])
+		}
+	}
+}
+
+func TestMigrateAttributesStateEmpty(t *testing.T) {
+	is, err := migrateAttributesState(0, &terraform.InstanceState{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if is.Attributes != nil {
+		t.Fatalf("Expected no attributes, got %v", is.Attributes)
+	}
+
+	if _, err := migrateAttributesState(5, &terraform.InstanceState{}, nil); err == nil {
+		t.Fatal("Expected an error for an unknown schema version")
+	}
+}
+
+func TestValidateJSONObject(t *testing.T) {
+	cases := map[string]bool{
+		`{}`:                              true,
+		`{"owner": "ops", "tier": 2}`:     true,
+		`{"backup": {"policy": "daily"}}`: true,
+		`[]`:                              false,
+		`"owner"`:                         false,
+		`{"owner":`:                       false,
+	}
+
+	for value, valid := range cases {
+		_, errs := validateJSONObject(value, "attributes_json")
+		if valid && len(errs) > 0 {
+			t.Errorf("Expected %q to be valid, got %v", value, errs)
+		}
+		if !valid && len(errs) == 0 {
+			t.Errorf("Expected %q to be invalid", value)
+		}
+	}
+}
+
+func TestSuppressEquivalentJSON(t *testing.T) {
+	if !suppressEquivalentJSON("attributes_json", `{"a":1,"b":{"c":"d"}}`, `{ "b": {"c": "d"}, "a": 1 }`, nil) {
+		t.Error("Expected documents with different formatting to be equivalent")
+	}
+	if suppressEquivalentJSON("attributes_json", `{"a":1}`, `{"a":2}`, nil) {
+		t.Error("Expected documents with different values to differ")
+	}
+	if suppressEquivalentJSON("attributes_json", ``, `{"a":1}`, nil) {
+		t.Error("Expected a new document to differ from an empty one")
+	}
+}
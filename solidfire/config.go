@@ -1,10 +1,21 @@
 package solidfire
 
 import (
+	"context"
+	"crypto/sha256"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/solidfire/terraform-provider-solidfire/solidfire/element"
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element/jsonrpc"
 )
 
 type Config struct {
@@ -12,8 +23,22 @@ type Config struct {
 	Password        string
 	SolidFireServer string
 	APIVersion      string
+	MaxRetries      int
+	RetryMinBackoff int
+	RetryMaxBackoff int
+	RetryableErrors []string
+	StopContext     context.Context
+	CAFile          string
+	CACert          string
+	Insecure        bool
+	CertFingerprint string
+	TLSServerName   string
 }
 
+// wrapTransport, when set, wraps the HTTP transport of every client. The acceptance tests use it to record
+// and replay API interactions.
+var wrapTransport func(http.RoundTripper) http.RoundTripper
+
 type Client struct {
 	Endpoint string
 }
@@ -28,17 +53,173 @@ type APIError struct {
 }
 
 func (c *Config) Client() (*element.Client, error) {
-	client := &element.Client{
-		Host:     "https://" + c.SolidFireServer,
-		Username: c.User,
-		Password: c.Password,
-		HTTPTransport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true},
+	tlsConfig, err := c.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var transport http.RoundTripper = &tlsErrorTransport{
+		server: c.SolidFireServer,
+		transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
 		},
 	}
+	if wrapTransport != nil {
+		transport = wrapTransport(transport)
+	}
+
+	client := &element.Client{
+		Host:          "https://" + c.SolidFireServer,
+		Username:      c.User,
+		Password:      c.Password,
+		HTTPTransport: transport,
+		RetryPolicy:   c.retryPolicy(),
+	}
 
 	client.SetAPIVersion(c.APIVersion)
+	if c.StopContext != nil {
+		client.SetContext(c.StopContext)
+	}
 
 	return client, nil
 }
+
+func (c *Config) retryPolicy() *jsonrpc.RetryPolicy {
+	if c.MaxRetries <= 0 {
+		return nil
+	}
+
+	policy := &jsonrpc.RetryPolicy{
+		MaxRetries: c.MaxRetries,
+		MinBackoff: time.Duration(c.RetryMinBackoff) * time.Second,
+		MaxBackoff: time.Duration(c.RetryMaxBackoff) * time.Second,
+		Jitter:     0.5,
+	}
+
+	if len(c.RetryableErrors) > 0 {
+		policy.RetryableErrorNames = append(policy.RetryableErrorNames, jsonrpc.DefaultRetryableErrorNames...)
+		policy.RetryableErrorNames = append(policy.RetryableErrorNames, c.RetryableErrors...)
+	}
+
+	return policy
+}
+
+// tlsConfig builds the TLS settings used to connect to the cluster MVIP. The server certificate is
+// verified against the system roots, or the configured CA bundle, unless a fingerprint is pinned or
+// verification is explicitly disabled with insecure.
+func (c *Config) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName: c.TLSServerName,
+	}
+
+	// A pinned fingerprint replaces chain verification, so a CA bundle would be silently ignored
+	if c.CertFingerprint != "" && (c.CAFile != "" || c.CACert != "") {
+		return nil, fmt.Errorf("cert_fingerprint cannot be combined with ca_file or ca_cert")
+	}
+
+	if c.Insecure {
+		log.Printf("[WARN] TLS certificate verification for %v is disabled", c.SolidFireServer)
+		config.InsecureSkipVerify = true
+		return config, nil
+	}
+
+	if c.CAFile != "" || c.CACert != "" {
+		pem := []byte(c.CACert)
+		if c.CAFile != "" {
+			contents, err := ioutil.ReadFile(c.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("Error reading ca_file %v: %v", c.CAFile, err)
+			}
+			pem = contents
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("No PEM encoded certificates found in the configured CA bundle")
+		}
+		config.RootCAs = pool
+	}
+
+	if c.CertFingerprint != "" {
+		expected, err := parseCertFingerprint(c.CertFingerprint)
+		if err != nil {
+			return nil, err
+		}
+
+		// The pinned fingerprint replaces chain verification, which allows the self-signed
+		// certificate a cluster ships with to be trusted without disabling verification.
+		config.InsecureSkipVerify = true
+		config.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return &certFingerprintError{expected: expected}
+			}
+			actual := sha256.Sum256(rawCerts[0])
+			if hex.EncodeToString(actual[:]) != expected {
+				return &certFingerprintError{expected: expected, actual: hex.EncodeToString(actual[:])}
+			}
+			return nil
+		}
+	}
+
+	return config, nil
+}
+
+// parseCertFingerprint normalizes a SHA-256 fingerprint given as hex, optionally separated by colons
+func parseCertFingerprint(fingerprint string) (string, error) {
+	normalized := strings.ToLower(strings.Replace(fingerprint, ":", "", -1))
+	decoded, err := hex.DecodeString(normalized)
+	if err != nil || len(decoded) != sha256.Size {
+		return "", fmt.Errorf("cert_fingerprint must be a SHA-256 fingerprint of %v hex encoded bytes, got %q", sha256.Size, fingerprint)
+	}
+	return normalized, nil
+}
+
+type certFingerprintError struct {
+	expected string
+	actual   string
+}
+
+func (e *certFingerprintError) Error() string {
+	if e.actual == "" {
+		return "server presented no certificate"
+	}
+	return fmt.Sprintf("server certificate has SHA-256 fingerprint %v, expected %v", e.actual, e.expected)
+}
+
+// tlsErrorTransport replaces certificate verification failures with an error explaining how to
+// configure the provider to trust the cluster
+type tlsErrorTransport struct {
+	server    string
+	transport http.RoundTripper
+}
+
+func (t *tlsErrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, describeTLSError(t.server, err)
+	}
+	return res, nil
+}
+
+func describeTLSError(server string, err error) error {
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+	var invalid x509.CertificateInvalidError
+	var fingerprint *certFingerprintError
+
+	switch {
+	case errors.As(err, &unknownAuthority):
+		return fmt.Errorf("Unable to verify the TLS certificate of %v because it is signed by an unknown authority. "+
+			"Set ca_file or ca_cert to the CA that issued it, pin the certificate with cert_fingerprint, "+
+			"or set insecure to skip verification: %w", server, err)
+	case errors.As(err, &hostname):
+		return fmt.Errorf("The TLS certificate of %v is not valid for that host name. "+
+			"Set tls_server_name to a name the certificate is issued for: %w", server, err)
+	case errors.As(err, &invalid):
+		return fmt.Errorf("The TLS certificate of %v is invalid: %w", server, err)
+	case errors.As(err, &fingerprint):
+		return fmt.Errorf("The TLS certificate of %v does not match cert_fingerprint: %w", server, err)
+	}
+
+	return err
+}
@@ -0,0 +1,109 @@
+package solidfire
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestTLSServer() *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result": {"volumes": []}}`))
+	}))
+}
+
+func testConfigForServer(server *httptest.Server) Config {
+	return Config{
+		User:            "user",
+		Password:        "pass",
+		SolidFireServer: strings.TrimPrefix(server.URL, "https://"),
+		APIVersion:      "9.0",
+	}
+}
+
+func TestConfigTLSUnknownAuthority(t *testing.T) {
+	server := newTestTLSServer()
+	defer server.Close()
+
+	config := testConfigForServer(server)
+	client, err := config.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.CallAPIMethod("ListVolumes", nil)
+	if err == nil {
+		t.Fatal("Expected certificate verification to fail")
+	}
+	if !strings.Contains(err.Error(), "unknown authority") {
+		t.Fatalf("Expected an unknown authority error, got: %s", err)
+	}
+}
+
+func TestConfigTLSCACert(t *testing.T) {
+	server := newTestTLSServer()
+	defer server.Close()
+
+	config := testConfigForServer(server)
+	config.CACert = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	config.TLSServerName = "example.com"
+	client, err := config.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.CallAPIMethod("ListVolumes", nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConfigTLSFingerprint(t *testing.T) {
+	server := newTestTLSServer()
+	defer server.Close()
+
+	sum := sha256.Sum256(server.Certificate().Raw)
+
+	config := testConfigForServer(server)
+	config.CertFingerprint = strings.ToUpper(hex.EncodeToString(sum[:]))
+	client, err := config.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.CallAPIMethod("ListVolumes", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	config.CertFingerprint = strings.Repeat("ab:", 31) + "ab"
+	client, err = config.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.CallAPIMethod("ListVolumes", nil)
+	if err == nil || !strings.Contains(err.Error(), "does not match cert_fingerprint") {
+		t.Fatalf("Expected a fingerprint mismatch error, got: %v", err)
+	}
+}
+
+func TestConfigTLSInvalidFingerprint(t *testing.T) {
+	config := Config{CertFingerprint: "not-a-fingerprint"}
+	if _, err := config.Client(); err == nil {
+		t.Fatal("Expected an invalid fingerprint to be rejected")
+	}
+}
+
+func TestConfigTLSFingerprintWithCA(t *testing.T) {
+	config := Config{
+		CertFingerprint: strings.Repeat("ab", sha256.Size),
+		CACert:          "-----BEGIN CERTIFICATE-----",
+	}
+	if _, err := config.Client(); err == nil || !strings.Contains(err.Error(), "cannot be combined") {
+		t.Fatalf("Expected a fingerprint with a CA bundle to be rejected, got: %v", err)
+	}
+}
@@ -0,0 +1,1303 @@
+// Code generated by apigen from api.json. DO NOT EDIT.
+
+package element
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/fatih/structs"
+)
+
+// Account is a tenant account that owns volumes
+type Account struct {
+	AccountID          int         `json:"accountID" structs:"accountID"`
+	Username           string      `json:"username" structs:"username"`
+	Status             string      `json:"status" structs:"status"`
+	Volumes            []int       `json:"volumes" structs:"volumes"`
+	InitiatorSecret    string      `json:"initiatorSecret" structs:"initiatorSecret"`
+	TargetSecret       string      `json:"targetSecret" structs:"targetSecret"`
+	EnableChap         bool        `json:"enableChap" structs:"enableChap"`
+	StorageContainerID string      `json:"storageContainerID" structs:"storageContainerID"`
+	Attributes         interface{} `json:"attributes" structs:"attributes"`
+}
+
+// QoS is the quality of service settings of a volume
+type QoS struct {
+	MinIOPS   int `json:"minIOPS,omitempty" structs:"minIOPS,omitempty"`
+	MaxIOPS   int `json:"maxIOPS,omitempty" structs:"maxIOPS,omitempty"`
+	BurstIOPS int `json:"burstIOPS,omitempty" structs:"burstIOPS,omitempty"`
+	BurstTime int `json:"burstTime,omitempty" structs:"burstTime,omitempty"`
+}
+
+// Volume is a block storage volume
+type Volume struct {
+	VolumeID           int          `json:"volumeID" structs:"volumeID"`
+	Name               string       `json:"name" structs:"name"`
+	AccountID          int          `json:"accountID" structs:"accountID"`
+	CreateTime         string       `json:"createTime" structs:"createTime"`
+	VolumeAccessGroups []int        `json:"volumeAccessGroups" structs:"volumeAccessGroups"`
+	Status             string       `json:"status" structs:"status"`
+	Access             string       `json:"access" structs:"access"`
+	Enable512e         bool         `json:"enable512e" structs:"enable512e"`
+	Iqn                string       `json:"iqn" structs:"iqn"`
+	ScsiEUIDeviceID    string       `json:"scsiEUIDeviceID" structs:"scsiEUIDeviceID"`
+	ScsiNAADeviceID    string       `json:"scsiNAADeviceID" structs:"scsiNAADeviceID"`
+	QoS                QoS          `json:"qos" structs:"qos"`
+	QoSPolicyID        *int         `json:"qosPolicyID" structs:"qosPolicyID"`
+	TotalSize          int          `json:"totalSize" structs:"totalSize"`
+	BlockSize          int          `json:"blockSize" structs:"blockSize"`
+	SliceCount         int          `json:"sliceCount" structs:"sliceCount"`
+	DeleteTime         string       `json:"deleteTime" structs:"deleteTime"`
+	PurgeTime          string       `json:"purgeTime" structs:"purgeTime"`
+	VirtualVolumeID    string       `json:"virtualVolumeID" structs:"virtualVolumeID"`
+	VolumePairs        []VolumePair `json:"volumePairs" structs:"volumePairs"`
+	FifoSize           int          `json:"fifoSize" structs:"fifoSize"`
+	MinFifoSize        int          `json:"minFifoSize" structs:"minFifoSize"`
+	Attributes         interface{}  `json:"attributes" structs:"attributes"`
+}
+
+// VolumePair is a replication relationship between a volume and a volume on a paired cluster
+type VolumePair struct {
+	ClusterPairID     int               `json:"clusterPairID" structs:"clusterPairID"`
+	RemoteVolumeID    int               `json:"remoteVolumeID" structs:"remoteVolumeID"`
+	RemoteSliceID     int               `json:"remoteSliceID" structs:"remoteSliceID"`
+	RemoteVolumeName  string            `json:"remoteVolumeName" structs:"remoteVolumeName"`
+	VolumePairUUID    string            `json:"volumePairUUID" structs:"volumePairUUID"`
+	RemoteReplication RemoteReplication `json:"remoteReplication" structs:"remoteReplication"`
+}
+
+// RemoteReplication is the replication state of a volume pair
+type RemoteReplication struct {
+	Mode            string `json:"mode" structs:"mode"`
+	PauseLimit      int    `json:"pauseLimit" structs:"pauseLimit"`
+	RemoteServiceID int    `json:"remoteServiceID" structs:"remoteServiceID"`
+	State           string `json:"state" structs:"state"`
+	StateDetails    string `json:"stateDetails" structs:"stateDetails"`
+}
+
+// Initiator is an iSCSI or Fibre Channel initiator known to the cluster
+type Initiator struct {
+	InitiatorID        int         `json:"initiatorID" structs:"initiatorID"`
+	InitiatorName      string      `json:"initiatorName" structs:"initiatorName"`
+	Alias              string      `json:"alias" structs:"alias"`
+	VolumeAccessGroups []int       `json:"volumeAccessGroups" structs:"volumeAccessGroups"`
+	Attributes         interface{} `json:"attributes" structs:"attributes"`
+}
+
+// CreateInitiator describes an initiator to create with CreateInitiators
+type CreateInitiator struct {
+	Name                string      `json:"name" structs:"name"`
+	Alias               string      `json:"alias,omitempty" structs:"alias,omitempty"`
+	VolumeAccessGroupID int         `json:"volumeAccessGroupID,omitempty" structs:"volumeAccessGroupID,omitempty"`
+	Attributes          interface{} `json:"attributes,omitempty" structs:"attributes,omitempty"`
+}
+
+// ModifyInitiator describes changes to an initiator made with ModifyInitiators
+type ModifyInitiator struct {
+	InitiatorID         int         `json:"initiatorID" structs:"initiatorID"`
+	Alias               string      `json:"alias,omitempty" structs:"alias,omitempty"`
+	VolumeAccessGroupID int         `json:"volumeAccessGroupID,omitempty" structs:"volumeAccessGroupID,omitempty"`
+	Attributes          interface{} `json:"attributes,omitempty" structs:"attributes,omitempty"`
+}
+
+// VolumeAccessGroup grants a set of initiators access to a set of volumes
+type VolumeAccessGroup struct {
+	VolumeAccessGroupID int         `json:"volumeAccessGroupID" structs:"volumeAccessGroupID"`
+	Name                string      `json:"name" structs:"name"`
+	Initiators          []string    `json:"initiators" structs:"initiators"`
+	InitiatorIDs        []int       `json:"initiatorIDs" structs:"initiatorIDs"`
+	Volumes             []int       `json:"volumes" structs:"volumes"`
+	DeletedVolumes      []int       `json:"deletedVolumes" structs:"deletedVolumes"`
+	Attributes          interface{} `json:"attributes" structs:"attributes"`
+}
+
+// ISCSISession is an active iSCSI connection between an initiator and a volume
+type ISCSISession struct {
+	SessionID     int    `json:"sessionID" structs:"sessionID"`
+	VolumeID      int    `json:"volumeID" structs:"volumeID"`
+	AccountID     int    `json:"accountID" structs:"accountID"`
+	InitiatorName string `json:"initiatorName" structs:"initiatorName"`
+	InitiatorIP   string `json:"initiatorIP" structs:"initiatorIP"`
+	TargetName    string `json:"targetName" structs:"targetName"`
+	TargetIP      string `json:"targetIP" structs:"targetIP"`
+	NodeID        int    `json:"nodeID" structs:"nodeID"`
+	ServiceID     int    `json:"serviceID" structs:"serviceID"`
+	CreateTime    string `json:"createTime" structs:"createTime"`
+}
+
+// Snapshot is a point-in-time copy of a volume
+type Snapshot struct {
+	SnapshotID              int         `json:"snapshotID" structs:"snapshotID"`
+	VolumeID                int         `json:"volumeID" structs:"volumeID"`
+	Name                    string      `json:"name" structs:"name"`
+	Checksum                string      `json:"checksum" structs:"checksum"`
+	EnableRemoteReplication bool        `json:"enableRemoteReplication" structs:"enableRemoteReplication"`
+	ExpirationReason        string      `json:"expirationReason" structs:"expirationReason"`
+	ExpirationTime          string      `json:"expirationTime" structs:"expirationTime"`
+	Status                  string      `json:"status" structs:"status"`
+	SnapshotUUID            string      `json:"snapshotUUID" structs:"snapshotUUID"`
+	TotalSize               int         `json:"totalSize" structs:"totalSize"`
+	GroupID                 int         `json:"groupID" structs:"groupID"`
+	GroupSnapshotUUID       string      `json:"groupSnapshotUUID" structs:"groupSnapshotUUID"`
+	CreateTime              string      `json:"createTime" structs:"createTime"`
+	SnapMirrorLabel         string      `json:"snapMirrorLabel" structs:"snapMirrorLabel"`
+	Attributes              interface{} `json:"attributes" structs:"attributes"`
+}
+
+// GroupSnapshotMember is the snapshot of one volume taken by CreateGroupSnapshot
+type GroupSnapshotMember struct {
+	VolumeID     int    `json:"volumeID" structs:"volumeID"`
+	SnapshotID   int    `json:"snapshotID" structs:"snapshotID"`
+	SnapshotUUID string `json:"snapshotUUID" structs:"snapshotUUID"`
+	Checksum     string `json:"checksum" structs:"checksum"`
+}
+
+// GroupSnapshot is a set of snapshots of several volumes taken at the same point in time
+type GroupSnapshot struct {
+	GroupSnapshotID         int         `json:"groupSnapshotID" structs:"groupSnapshotID"`
+	GroupSnapshotUUID       string      `json:"groupSnapshotUUID" structs:"groupSnapshotUUID"`
+	Name                    string      `json:"name" structs:"name"`
+	Members                 []Snapshot  `json:"members" structs:"members"`
+	CreateTime              string      `json:"createTime" structs:"createTime"`
+	Status                  string      `json:"status" structs:"status"`
+	EnableRemoteReplication bool        `json:"enableRemoteReplication" structs:"enableRemoteReplication"`
+	Attributes              interface{} `json:"attributes" structs:"attributes"`
+}
+
+// ScheduleWeekday is a day of the week on which a schedule runs
+type ScheduleWeekday struct {
+	Day    int `json:"day" structs:"day"`
+	Offset int `json:"offset" structs:"offset"`
+}
+
+// ScheduleInfo describes the snapshots taken by a schedule, of a single volume or of a group of volumes
+type ScheduleInfo struct {
+	VolumeID                int    `json:"volumeID,omitempty" structs:"volumeID,omitempty"`
+	Volumes                 []int  `json:"volumes,omitempty" structs:"volumes,omitempty"`
+	Name                    string `json:"name,omitempty" structs:"name,omitempty"`
+	EnableRemoteReplication bool   `json:"enableRemoteReplication,omitempty" structs:"enableRemoteReplication,omitempty"`
+	Retention               string `json:"retention,omitempty" structs:"retention,omitempty"`
+}
+
+// Schedule takes snapshots at regular times
+type Schedule struct {
+	ScheduleID         int               `json:"scheduleID" structs:"scheduleID"`
+	ScheduleName       string            `json:"scheduleName" structs:"scheduleName"`
+	ScheduleType       string            `json:"scheduleType" structs:"scheduleType"`
+	ScheduleInfo       ScheduleInfo      `json:"scheduleInfo" structs:"scheduleInfo"`
+	Attributes         interface{}       `json:"attributes" structs:"attributes"`
+	Hours              int               `json:"hours" structs:"hours"`
+	Minutes            int               `json:"minutes" structs:"minutes"`
+	Weekdays           []ScheduleWeekday `json:"weekdays" structs:"weekdays"`
+	Monthdays          []int             `json:"monthdays" structs:"monthdays"`
+	Paused             bool              `json:"paused" structs:"paused"`
+	Recurring          bool              `json:"recurring" structs:"recurring"`
+	RunNextInterval    bool              `json:"runNextInterval" structs:"runNextInterval"`
+	StartingDate       string            `json:"startingDate" structs:"startingDate"`
+	LastRunTimeStarted string            `json:"lastRunTimeStarted" structs:"lastRunTimeStarted"`
+	LastRunStatus      string            `json:"lastRunStatus" structs:"lastRunStatus"`
+	HasError           bool              `json:"hasError" structs:"hasError"`
+	ToBeDeleted        bool              `json:"toBeDeleted" structs:"toBeDeleted"`
+}
+
+// QoSPolicy is a named set of QoS settings shared by volumes
+type QoSPolicy struct {
+	QoSPolicyID int    `json:"qosPolicyID" structs:"qosPolicyID"`
+	Name        string `json:"name" structs:"name"`
+	QoS         QoS    `json:"qos" structs:"qos"`
+	VolumeIDs   []int  `json:"volumeIDs" structs:"volumeIDs"`
+}
+
+// AsyncError describes why a long-running operation failed
+type AsyncError struct {
+	Name    string `json:"name" structs:"name"`
+	Message string `json:"message" structs:"message"`
+}
+
+// AsyncHandle summarizes a long-running operation returned by ListAsyncResults
+type AsyncHandle struct {
+	AsyncResultID  int              `json:"asyncResultID" structs:"asyncResultID"`
+	Completed      bool             `json:"completed" structs:"completed"`
+	Success        bool             `json:"success" structs:"success"`
+	ResultType     string           `json:"resultType" structs:"resultType"`
+	CreateTime     string           `json:"createTime" structs:"createTime"`
+	LastUpdateTime string           `json:"lastUpdateTime" structs:"lastUpdateTime"`
+	Data           *json.RawMessage `json:"data" structs:"data"`
+}
+
+// AddAccountRequest holds the parameters of AddAccount
+type AddAccountRequest struct {
+	Username        string      `json:"username" structs:"username"`
+	InitiatorSecret string      `json:"initiatorSecret,omitempty" structs:"initiatorSecret,omitempty"`
+	TargetSecret    string      `json:"targetSecret,omitempty" structs:"targetSecret,omitempty"`
+	EnableChap      *bool       `json:"enableChap,omitempty" structs:"enableChap,omitempty"`
+	Attributes      interface{} `json:"attributes,omitempty" structs:"attributes,omitempty"`
+}
+
+// AddAccountResult holds the result of AddAccount
+type AddAccountResult struct {
+	AccountID int     `json:"accountID" structs:"accountID"`
+	Account   Account `json:"account" structs:"account"`
+}
+
+// AddAccount creates an account
+func (c *Client) AddAccount(request AddAccountRequest) (AddAccountResult, error) {
+	return c.AddAccountContext(c.Context(), request)
+}
+
+// AddAccountContext is like AddAccount but is canceled with ctx
+func (c *Client) AddAccountContext(ctx context.Context, request AddAccountRequest) (AddAccountResult, error) {
+	var result AddAccountResult
+	err := c.callMethod(ctx, "AddAccount", structs.Map(request), &result)
+	return result, err
+}
+
+// GetAccountByIDRequest holds the parameters of GetAccountByID
+type GetAccountByIDRequest struct {
+	AccountID int `json:"accountID" structs:"accountID"`
+}
+
+// GetAccountByIDResult holds the result of GetAccountByID
+type GetAccountByIDResult struct {
+	Account Account `json:"account" structs:"account"`
+}
+
+// GetAccountByID returns the account with the given ID
+func (c *Client) GetAccountByID(request GetAccountByIDRequest) (GetAccountByIDResult, error) {
+	return c.GetAccountByIDContext(c.Context(), request)
+}
+
+// GetAccountByIDContext is like GetAccountByID but is canceled with ctx
+func (c *Client) GetAccountByIDContext(ctx context.Context, request GetAccountByIDRequest) (GetAccountByIDResult, error) {
+	var result GetAccountByIDResult
+	err := c.callMethod(ctx, "GetAccountByID", structs.Map(request), &result)
+	return result, err
+}
+
+// ModifyAccountRequest holds the parameters of ModifyAccount
+type ModifyAccountRequest struct {
+	AccountID       int         `json:"accountID" structs:"accountID"`
+	Username        string      `json:"username,omitempty" structs:"username,omitempty"`
+	Status          string      `json:"status,omitempty" structs:"status,omitempty"`
+	InitiatorSecret string      `json:"initiatorSecret,omitempty" structs:"initiatorSecret,omitempty"`
+	TargetSecret    string      `json:"targetSecret,omitempty" structs:"targetSecret,omitempty"`
+	EnableChap      *bool       `json:"enableChap,omitempty" structs:"enableChap,omitempty"`
+	Attributes      interface{} `json:"attributes,omitempty" structs:"attributes,omitempty"`
+}
+
+// ModifyAccountResult holds the result of ModifyAccount
+type ModifyAccountResult struct {
+	Account Account `json:"account" structs:"account"`
+}
+
+// ModifyAccount changes the settings of an account
+func (c *Client) ModifyAccount(request ModifyAccountRequest) (ModifyAccountResult, error) {
+	return c.ModifyAccountContext(c.Context(), request)
+}
+
+// ModifyAccountContext is like ModifyAccount but is canceled with ctx
+func (c *Client) ModifyAccountContext(ctx context.Context, request ModifyAccountRequest) (ModifyAccountResult, error) {
+	var result ModifyAccountResult
+	err := c.callMethod(ctx, "ModifyAccount", structs.Map(request), &result)
+	return result, err
+}
+
+// RemoveAccountRequest holds the parameters of RemoveAccount
+type RemoveAccountRequest struct {
+	AccountID int `json:"accountID" structs:"accountID"`
+}
+
+// RemoveAccountResult holds the result of RemoveAccount
+type RemoveAccountResult struct {
+}
+
+// RemoveAccount deletes an account that owns no volumes
+func (c *Client) RemoveAccount(request RemoveAccountRequest) (RemoveAccountResult, error) {
+	return c.RemoveAccountContext(c.Context(), request)
+}
+
+// RemoveAccountContext is like RemoveAccount but is canceled with ctx
+func (c *Client) RemoveAccountContext(ctx context.Context, request RemoveAccountRequest) (RemoveAccountResult, error) {
+	var result RemoveAccountResult
+	err := c.callMethod(ctx, "RemoveAccount", structs.Map(request), &result)
+	return result, err
+}
+
+// CreateVolumeRequest holds the parameters of CreateVolume
+type CreateVolumeRequest struct {
+	Name        string      `json:"name" structs:"name"`
+	AccountID   int         `json:"accountID" structs:"accountID"`
+	TotalSize   int         `json:"totalSize" structs:"totalSize"`
+	Enable512e  bool        `json:"enable512e" structs:"enable512e"`
+	QoS         *QoS        `json:"qos,omitempty" structs:"qos,omitempty"`
+	QoSPolicyID int         `json:"qosPolicyID,omitempty" structs:"qosPolicyID,omitempty"`
+	FifoSize    int         `json:"fifoSize,omitempty" structs:"fifoSize,omitempty"`
+	MinFifoSize *int        `json:"minFifoSize,omitempty" structs:"minFifoSize,omitempty"`
+	Attributes  interface{} `json:"attributes,omitempty" structs:"attributes,omitempty"`
+}
+
+// CreateVolumeResult holds the result of CreateVolume
+type CreateVolumeResult struct {
+	VolumeID int    `json:"volumeID" structs:"volumeID"`
+	Volume   Volume `json:"volume" structs:"volume"`
+}
+
+// CreateVolume creates a volume
+func (c *Client) CreateVolume(request CreateVolumeRequest) (CreateVolumeResult, error) {
+	return c.CreateVolumeContext(c.Context(), request)
+}
+
+// CreateVolumeContext is like CreateVolume but is canceled with ctx
+func (c *Client) CreateVolumeContext(ctx context.Context, request CreateVolumeRequest) (CreateVolumeResult, error) {
+	var result CreateVolumeResult
+	err := c.callMethod(ctx, "CreateVolume", structs.Map(request), &result)
+	return result, err
+}
+
+// ListVolumesRequest holds the parameters of ListVolumes
+type ListVolumesRequest struct {
+	StartVolumeID         int    `json:"startVolumeID,omitempty" structs:"startVolumeID,omitempty"`
+	Limit                 int    `json:"limit,omitempty" structs:"limit,omitempty"`
+	VolumeStatus          string `json:"volumeStatus,omitempty" structs:"volumeStatus,omitempty"`
+	Accounts              []int  `json:"accounts,omitempty" structs:"accounts,omitempty"`
+	VolumeIDs             []int  `json:"volumeIDs,omitempty" structs:"volumeIDs,omitempty"`
+	VolumeName            string `json:"volumeName,omitempty" structs:"volumeName,omitempty"`
+	IncludeVirtualVolumes bool   `json:"includeVirtualVolumes" structs:"includeVirtualVolumes"`
+}
+
+// ListVolumesResult holds the result of ListVolumes
+type ListVolumesResult struct {
+	Volumes []Volume `json:"volumes" structs:"volumes"`
+}
+
+// ListVolumes returns volumes, optionally filtered by ID, account or status
+func (c *Client) ListVolumes(request ListVolumesRequest) (ListVolumesResult, error) {
+	return c.ListVolumesContext(c.Context(), request)
+}
+
+// ListVolumesContext is like ListVolumes but is canceled with ctx
+func (c *Client) ListVolumesContext(ctx context.Context, request ListVolumesRequest) (ListVolumesResult, error) {
+	var result ListVolumesResult
+	err := c.callMethod(ctx, "ListVolumes", structs.Map(request), &result)
+	return result, err
+}
+
+// ModifyVolumeRequest holds the parameters of ModifyVolume
+type ModifyVolumeRequest struct {
+	VolumeID               int         `json:"volumeID" structs:"volumeID"`
+	AccountID              int         `json:"accountID,omitempty" structs:"accountID,omitempty"`
+	Access                 string      `json:"access,omitempty" structs:"access,omitempty"`
+	QoS                    *QoS        `json:"qos,omitempty" structs:"qos,omitempty"`
+	QoSPolicyID            int         `json:"qosPolicyID,omitempty" structs:"qosPolicyID,omitempty"`
+	AssociateWithQoSPolicy *bool       `json:"associateWithQoSPolicy,omitempty" structs:"associateWithQoSPolicy,omitempty"`
+	TotalSize              int         `json:"totalSize,omitempty" structs:"totalSize,omitempty"`
+	FifoSize               int         `json:"fifoSize,omitempty" structs:"fifoSize,omitempty"`
+	MinFifoSize            *int        `json:"minFifoSize,omitempty" structs:"minFifoSize,omitempty"`
+	Attributes             interface{} `json:"attributes,omitempty" structs:"attributes,omitempty"`
+}
+
+// ModifyVolumeResult holds the result of ModifyVolume
+type ModifyVolumeResult struct {
+	Volume Volume `json:"volume" structs:"volume"`
+}
+
+// ModifyVolume changes the settings of a volume
+func (c *Client) ModifyVolume(request ModifyVolumeRequest) (ModifyVolumeResult, error) {
+	return c.ModifyVolumeContext(c.Context(), request)
+}
+
+// ModifyVolumeContext is like ModifyVolume but is canceled with ctx
+func (c *Client) ModifyVolumeContext(ctx context.Context, request ModifyVolumeRequest) (ModifyVolumeResult, error) {
+	var result ModifyVolumeResult
+	err := c.callMethod(ctx, "ModifyVolume", structs.Map(request), &result)
+	return result, err
+}
+
+// DeleteVolumeRequest holds the parameters of DeleteVolume
+type DeleteVolumeRequest struct {
+	VolumeID int `json:"volumeID" structs:"volumeID"`
+}
+
+// DeleteVolumeResult holds the result of DeleteVolume
+type DeleteVolumeResult struct {
+	Volume Volume `json:"volume" structs:"volume"`
+}
+
+// DeleteVolume marks a volume for deletion. The volume is purged when its retention period expires.
+func (c *Client) DeleteVolume(request DeleteVolumeRequest) (DeleteVolumeResult, error) {
+	return c.DeleteVolumeContext(c.Context(), request)
+}
+
+// DeleteVolumeContext is like DeleteVolume but is canceled with ctx
+func (c *Client) DeleteVolumeContext(ctx context.Context, request DeleteVolumeRequest) (DeleteVolumeResult, error) {
+	var result DeleteVolumeResult
+	err := c.callMethod(ctx, "DeleteVolume", structs.Map(request), &result)
+	return result, err
+}
+
+// PurgeDeletedVolumeRequest holds the parameters of PurgeDeletedVolume
+type PurgeDeletedVolumeRequest struct {
+	VolumeID int `json:"volumeID" structs:"volumeID"`
+}
+
+// PurgeDeletedVolumeResult holds the result of PurgeDeletedVolume
+type PurgeDeletedVolumeResult struct {
+}
+
+// PurgeDeletedVolume immediately and permanently removes a deleted volume
+func (c *Client) PurgeDeletedVolume(request PurgeDeletedVolumeRequest) (PurgeDeletedVolumeResult, error) {
+	return c.PurgeDeletedVolumeContext(c.Context(), request)
+}
+
+// PurgeDeletedVolumeContext is like PurgeDeletedVolume but is canceled with ctx
+func (c *Client) PurgeDeletedVolumeContext(ctx context.Context, request PurgeDeletedVolumeRequest) (PurgeDeletedVolumeResult, error) {
+	var result PurgeDeletedVolumeResult
+	err := c.callMethod(ctx, "PurgeDeletedVolume", structs.Map(request), &result)
+	return result, err
+}
+
+// CloneVolumeRequest holds the parameters of CloneVolume
+type CloneVolumeRequest struct {
+	VolumeID     int         `json:"volumeID" structs:"volumeID"`
+	Name         string      `json:"name" structs:"name"`
+	NewAccountID int         `json:"newAccountID,omitempty" structs:"newAccountID,omitempty"`
+	NewSize      int         `json:"newSize,omitempty" structs:"newSize,omitempty"`
+	Access       string      `json:"access,omitempty" structs:"access,omitempty"`
+	SnapshotID   int         `json:"snapshotID,omitempty" structs:"snapshotID,omitempty"`
+	Enable512e   *bool       `json:"enable512e,omitempty" structs:"enable512e,omitempty"`
+	Attributes   interface{} `json:"attributes,omitempty" structs:"attributes,omitempty"`
+}
+
+// CloneVolumeResult holds the result of CloneVolume
+type CloneVolumeResult struct {
+	CloneID     int    `json:"cloneID" structs:"cloneID"`
+	VolumeID    int    `json:"volumeID" structs:"volumeID"`
+	AsyncHandle int    `json:"asyncHandle" structs:"asyncHandle"`
+	Volume      Volume `json:"volume" structs:"volume"`
+}
+
+// CloneVolume starts an asynchronous copy of a volume or of one of its snapshots
+func (c *Client) CloneVolume(request CloneVolumeRequest) (CloneVolumeResult, error) {
+	return c.CloneVolumeContext(c.Context(), request)
+}
+
+// CloneVolumeContext is like CloneVolume but is canceled with ctx
+func (c *Client) CloneVolumeContext(ctx context.Context, request CloneVolumeRequest) (CloneVolumeResult, error) {
+	var result CloneVolumeResult
+	err := c.callMethod(ctx, "CloneVolume", structs.Map(request), &result)
+	return result, err
+}
+
+// GetAsyncResultRequest holds the parameters of GetAsyncResult
+type GetAsyncResultRequest struct {
+	AsyncHandle int  `json:"asyncHandle" structs:"asyncHandle"`
+	KeepResult  bool `json:"keepResult" structs:"keepResult"`
+}
+
+// GetAsyncResultResult holds the result of GetAsyncResult
+type GetAsyncResultResult struct {
+	Status         string           `json:"status" structs:"status"`
+	ResultType     string           `json:"resultType" structs:"resultType"`
+	CreateTime     string           `json:"createTime" structs:"createTime"`
+	LastUpdateTime string           `json:"lastUpdateTime" structs:"lastUpdateTime"`
+	Details        *json.RawMessage `json:"details" structs:"details"`
+	Result         *json.RawMessage `json:"result" structs:"result"`
+	Error          *AsyncError      `json:"error" structs:"error"`
+}
+
+// GetAsyncResult returns the state of a long-running operation. Unless keepResult is set, a finished result is discarded once returned
+func (c *Client) GetAsyncResult(request GetAsyncResultRequest) (GetAsyncResultResult, error) {
+	return c.GetAsyncResultContext(c.Context(), request)
+}
+
+// GetAsyncResultContext is like GetAsyncResult but is canceled with ctx
+func (c *Client) GetAsyncResultContext(ctx context.Context, request GetAsyncResultRequest) (GetAsyncResultResult, error) {
+	var result GetAsyncResultResult
+	err := c.callMethod(ctx, "GetAsyncResult", structs.Map(request), &result)
+	return result, err
+}
+
+// ListAsyncResultsRequest holds the parameters of ListAsyncResults
+type ListAsyncResultsRequest struct {
+	AsyncResultTypes []string `json:"asyncResultTypes,omitempty" structs:"asyncResultTypes,omitempty"`
+}
+
+// ListAsyncResultsResult holds the result of ListAsyncResults
+type ListAsyncResultsResult struct {
+	AsyncHandles []AsyncHandle `json:"asyncHandles" structs:"asyncHandles"`
+}
+
+// ListAsyncResults lists the long-running operations whose results the cluster keeps, optionally filtered by result type
+func (c *Client) ListAsyncResults(request ListAsyncResultsRequest) (ListAsyncResultsResult, error) {
+	return c.ListAsyncResultsContext(c.Context(), request)
+}
+
+// ListAsyncResultsContext is like ListAsyncResults but is canceled with ctx
+func (c *Client) ListAsyncResultsContext(ctx context.Context, request ListAsyncResultsRequest) (ListAsyncResultsResult, error) {
+	var result ListAsyncResultsResult
+	err := c.callMethod(ctx, "ListAsyncResults", structs.Map(request), &result)
+	return result, err
+}
+
+// ListDeletedVolumesRequest holds the parameters of ListDeletedVolumes
+type ListDeletedVolumesRequest struct {
+	IncludeVirtualVolumes bool `json:"includeVirtualVolumes,omitempty" structs:"includeVirtualVolumes,omitempty"`
+}
+
+// ListDeletedVolumesResult holds the result of ListDeletedVolumes
+type ListDeletedVolumesResult struct {
+	Volumes []Volume `json:"volumes" structs:"volumes"`
+}
+
+// ListDeletedVolumes returns the volumes that are deleted but not yet purged
+func (c *Client) ListDeletedVolumes(request ListDeletedVolumesRequest) (ListDeletedVolumesResult, error) {
+	return c.ListDeletedVolumesContext(c.Context(), request)
+}
+
+// ListDeletedVolumesContext is like ListDeletedVolumes but is canceled with ctx
+func (c *Client) ListDeletedVolumesContext(ctx context.Context, request ListDeletedVolumesRequest) (ListDeletedVolumesResult, error) {
+	var result ListDeletedVolumesResult
+	err := c.callMethod(ctx, "ListDeletedVolumes", structs.Map(request), &result)
+	return result, err
+}
+
+// ListVolumesForAccountRequest holds the parameters of ListVolumesForAccount
+type ListVolumesForAccountRequest struct {
+	AccountID int `json:"accountID" structs:"accountID"`
+}
+
+// ListVolumesForAccountResult holds the result of ListVolumesForAccount
+type ListVolumesForAccountResult struct {
+	Volumes []Volume `json:"volumes" structs:"volumes"`
+}
+
+// ListVolumesForAccount returns the active and deleted but not yet purged volumes owned by an account
+func (c *Client) ListVolumesForAccount(request ListVolumesForAccountRequest) (ListVolumesForAccountResult, error) {
+	return c.ListVolumesForAccountContext(c.Context(), request)
+}
+
+// ListVolumesForAccountContext is like ListVolumesForAccount but is canceled with ctx
+func (c *Client) ListVolumesForAccountContext(ctx context.Context, request ListVolumesForAccountRequest) (ListVolumesForAccountResult, error) {
+	var result ListVolumesForAccountResult
+	err := c.callMethod(ctx, "ListVolumesForAccount", structs.Map(request), &result)
+	return result, err
+}
+
+// RestoreDeletedVolumeRequest holds the parameters of RestoreDeletedVolume
+type RestoreDeletedVolumeRequest struct {
+	VolumeID int `json:"volumeID" structs:"volumeID"`
+}
+
+// RestoreDeletedVolumeResult holds the result of RestoreDeletedVolume
+type RestoreDeletedVolumeResult struct {
+}
+
+// RestoreDeletedVolume marks a deleted volume as active again
+func (c *Client) RestoreDeletedVolume(request RestoreDeletedVolumeRequest) (RestoreDeletedVolumeResult, error) {
+	return c.RestoreDeletedVolumeContext(c.Context(), request)
+}
+
+// RestoreDeletedVolumeContext is like RestoreDeletedVolume but is canceled with ctx
+func (c *Client) RestoreDeletedVolumeContext(ctx context.Context, request RestoreDeletedVolumeRequest) (RestoreDeletedVolumeResult, error) {
+	var result RestoreDeletedVolumeResult
+	err := c.callMethod(ctx, "RestoreDeletedVolume", structs.Map(request), &result)
+	return result, err
+}
+
+// CreateSnapshotRequest holds the parameters of CreateSnapshot
+type CreateSnapshotRequest struct {
+	VolumeID                int         `json:"volumeID" structs:"volumeID"`
+	SnapshotID              int         `json:"snapshotID,omitempty" structs:"snapshotID,omitempty"`
+	Name                    string      `json:"name,omitempty" structs:"name,omitempty"`
+	EnableRemoteReplication bool        `json:"enableRemoteReplication,omitempty" structs:"enableRemoteReplication,omitempty"`
+	Retention               string      `json:"retention,omitempty" structs:"retention,omitempty"`
+	SnapMirrorLabel         string      `json:"snapMirrorLabel,omitempty" structs:"snapMirrorLabel,omitempty"`
+	Attributes              interface{} `json:"attributes,omitempty" structs:"attributes,omitempty"`
+}
+
+// CreateSnapshotResult holds the result of CreateSnapshot
+type CreateSnapshotResult struct {
+	SnapshotID int      `json:"snapshotID" structs:"snapshotID"`
+	Checksum   string   `json:"checksum" structs:"checksum"`
+	Snapshot   Snapshot `json:"snapshot" structs:"snapshot"`
+}
+
+// CreateSnapshot creates a point-in-time copy of a volume
+func (c *Client) CreateSnapshot(request CreateSnapshotRequest) (CreateSnapshotResult, error) {
+	return c.CreateSnapshotContext(c.Context(), request)
+}
+
+// CreateSnapshotContext is like CreateSnapshot but is canceled with ctx
+func (c *Client) CreateSnapshotContext(ctx context.Context, request CreateSnapshotRequest) (CreateSnapshotResult, error) {
+	var result CreateSnapshotResult
+	err := c.callMethod(ctx, "CreateSnapshot", structs.Map(request), &result)
+	return result, err
+}
+
+// ListSnapshotsRequest holds the parameters of ListSnapshots
+type ListSnapshotsRequest struct {
+	VolumeID   int `json:"volumeID,omitempty" structs:"volumeID,omitempty"`
+	SnapshotID int `json:"snapshotID,omitempty" structs:"snapshotID,omitempty"`
+}
+
+// ListSnapshotsResult holds the result of ListSnapshots
+type ListSnapshotsResult struct {
+	Snapshots []Snapshot `json:"snapshots" structs:"snapshots"`
+}
+
+// ListSnapshots returns the snapshots of a volume, or of all volumes
+func (c *Client) ListSnapshots(request ListSnapshotsRequest) (ListSnapshotsResult, error) {
+	return c.ListSnapshotsContext(c.Context(), request)
+}
+
+// ListSnapshotsContext is like ListSnapshots but is canceled with ctx
+func (c *Client) ListSnapshotsContext(ctx context.Context, request ListSnapshotsRequest) (ListSnapshotsResult, error) {
+	var result ListSnapshotsResult
+	err := c.callMethod(ctx, "ListSnapshots", structs.Map(request), &result)
+	return result, err
+}
+
+// ModifySnapshotRequest holds the parameters of ModifySnapshot
+type ModifySnapshotRequest struct {
+	SnapshotID              int    `json:"snapshotID" structs:"snapshotID"`
+	Name                    string `json:"name,omitempty" structs:"name,omitempty"`
+	ExpirationTime          string `json:"expirationTime,omitempty" structs:"expirationTime,omitempty"`
+	EnableRemoteReplication *bool  `json:"enableRemoteReplication,omitempty" structs:"enableRemoteReplication,omitempty"`
+	SnapMirrorLabel         string `json:"snapMirrorLabel,omitempty" structs:"snapMirrorLabel,omitempty"`
+}
+
+// ModifySnapshotResult holds the result of ModifySnapshot
+type ModifySnapshotResult struct {
+	Snapshot Snapshot `json:"snapshot" structs:"snapshot"`
+}
+
+// ModifySnapshot changes the name, expiration and replication settings of a snapshot
+func (c *Client) ModifySnapshot(request ModifySnapshotRequest) (ModifySnapshotResult, error) {
+	return c.ModifySnapshotContext(c.Context(), request)
+}
+
+// ModifySnapshotContext is like ModifySnapshot but is canceled with ctx
+func (c *Client) ModifySnapshotContext(ctx context.Context, request ModifySnapshotRequest) (ModifySnapshotResult, error) {
+	var result ModifySnapshotResult
+	err := c.callMethod(ctx, "ModifySnapshot", structs.Map(request), &result)
+	return result, err
+}
+
+// DeleteSnapshotRequest holds the parameters of DeleteSnapshot
+type DeleteSnapshotRequest struct {
+	SnapshotID int `json:"snapshotID" structs:"snapshotID"`
+}
+
+// DeleteSnapshotResult holds the result of DeleteSnapshot
+type DeleteSnapshotResult struct {
+}
+
+// DeleteSnapshot deletes a snapshot
+func (c *Client) DeleteSnapshot(request DeleteSnapshotRequest) (DeleteSnapshotResult, error) {
+	return c.DeleteSnapshotContext(c.Context(), request)
+}
+
+// DeleteSnapshotContext is like DeleteSnapshot but is canceled with ctx
+func (c *Client) DeleteSnapshotContext(ctx context.Context, request DeleteSnapshotRequest) (DeleteSnapshotResult, error) {
+	var result DeleteSnapshotResult
+	err := c.callMethod(ctx, "DeleteSnapshot", structs.Map(request), &result)
+	return result, err
+}
+
+// RollbackToSnapshotRequest holds the parameters of RollbackToSnapshot
+type RollbackToSnapshotRequest struct {
+	VolumeID         int         `json:"volumeID" structs:"volumeID"`
+	SnapshotID       int         `json:"snapshotID" structs:"snapshotID"`
+	SaveCurrentState bool        `json:"saveCurrentState" structs:"saveCurrentState"`
+	Name             string      `json:"name,omitempty" structs:"name,omitempty"`
+	Attributes       interface{} `json:"attributes,omitempty" structs:"attributes,omitempty"`
+}
+
+// RollbackToSnapshotResult holds the result of RollbackToSnapshot
+type RollbackToSnapshotResult struct {
+	Checksum   string    `json:"checksum" structs:"checksum"`
+	SnapshotID int       `json:"snapshotID" structs:"snapshotID"`
+	Snapshot   *Snapshot `json:"snapshot" structs:"snapshot"`
+}
+
+// RollbackToSnapshot restores a volume to a snapshot, optionally saving its current state as a new snapshot first
+func (c *Client) RollbackToSnapshot(request RollbackToSnapshotRequest) (RollbackToSnapshotResult, error) {
+	return c.RollbackToSnapshotContext(c.Context(), request)
+}
+
+// RollbackToSnapshotContext is like RollbackToSnapshot but is canceled with ctx
+func (c *Client) RollbackToSnapshotContext(ctx context.Context, request RollbackToSnapshotRequest) (RollbackToSnapshotResult, error) {
+	var result RollbackToSnapshotResult
+	err := c.callMethod(ctx, "RollbackToSnapshot", structs.Map(request), &result)
+	return result, err
+}
+
+// CreateGroupSnapshotRequest holds the parameters of CreateGroupSnapshot
+type CreateGroupSnapshotRequest struct {
+	Volumes                 []int       `json:"volumes" structs:"volumes"`
+	Name                    string      `json:"name,omitempty" structs:"name,omitempty"`
+	EnableRemoteReplication bool        `json:"enableRemoteReplication,omitempty" structs:"enableRemoteReplication,omitempty"`
+	Retention               string      `json:"retention,omitempty" structs:"retention,omitempty"`
+	SnapMirrorLabel         string      `json:"snapMirrorLabel,omitempty" structs:"snapMirrorLabel,omitempty"`
+	Attributes              interface{} `json:"attributes,omitempty" structs:"attributes,omitempty"`
+}
+
+// CreateGroupSnapshotResult holds the result of CreateGroupSnapshot
+type CreateGroupSnapshotResult struct {
+	GroupSnapshotID   int                   `json:"groupSnapshotID" structs:"groupSnapshotID"`
+	GroupSnapshotUUID string                `json:"groupSnapshotUUID" structs:"groupSnapshotUUID"`
+	Members           []GroupSnapshotMember `json:"members" structs:"members"`
+	GroupSnapshot     GroupSnapshot         `json:"groupSnapshot" structs:"groupSnapshot"`
+}
+
+// CreateGroupSnapshot takes crash-consistent snapshots of several volumes at the same point in time
+func (c *Client) CreateGroupSnapshot(request CreateGroupSnapshotRequest) (CreateGroupSnapshotResult, error) {
+	return c.CreateGroupSnapshotContext(c.Context(), request)
+}
+
+// CreateGroupSnapshotContext is like CreateGroupSnapshot but is canceled with ctx
+func (c *Client) CreateGroupSnapshotContext(ctx context.Context, request CreateGroupSnapshotRequest) (CreateGroupSnapshotResult, error) {
+	var result CreateGroupSnapshotResult
+	err := c.callMethod(ctx, "CreateGroupSnapshot", structs.Map(request), &result)
+	return result, err
+}
+
+// ListGroupSnapshotsRequest holds the parameters of ListGroupSnapshots
+type ListGroupSnapshotsRequest struct {
+	Volumes         []int `json:"volumes,omitempty" structs:"volumes,omitempty"`
+	GroupSnapshotID int   `json:"groupSnapshotID,omitempty" structs:"groupSnapshotID,omitempty"`
+}
+
+// ListGroupSnapshotsResult holds the result of ListGroupSnapshots
+type ListGroupSnapshotsResult struct {
+	GroupSnapshots []GroupSnapshot `json:"groupSnapshots" structs:"groupSnapshots"`
+}
+
+// ListGroupSnapshots returns the group snapshots that include any of the given volumes, or all group snapshots
+func (c *Client) ListGroupSnapshots(request ListGroupSnapshotsRequest) (ListGroupSnapshotsResult, error) {
+	return c.ListGroupSnapshotsContext(c.Context(), request)
+}
+
+// ListGroupSnapshotsContext is like ListGroupSnapshots but is canceled with ctx
+func (c *Client) ListGroupSnapshotsContext(ctx context.Context, request ListGroupSnapshotsRequest) (ListGroupSnapshotsResult, error) {
+	var result ListGroupSnapshotsResult
+	err := c.callMethod(ctx, "ListGroupSnapshots", structs.Map(request), &result)
+	return result, err
+}
+
+// ModifyGroupSnapshotRequest holds the parameters of ModifyGroupSnapshot
+type ModifyGroupSnapshotRequest struct {
+	GroupSnapshotID         int    `json:"groupSnapshotID" structs:"groupSnapshotID"`
+	Name                    string `json:"name,omitempty" structs:"name,omitempty"`
+	ExpirationTime          string `json:"expirationTime,omitempty" structs:"expirationTime,omitempty"`
+	EnableRemoteReplication *bool  `json:"enableRemoteReplication,omitempty" structs:"enableRemoteReplication,omitempty"`
+	SnapMirrorLabel         string `json:"snapMirrorLabel,omitempty" structs:"snapMirrorLabel,omitempty"`
+}
+
+// ModifyGroupSnapshotResult holds the result of ModifyGroupSnapshot
+type ModifyGroupSnapshotResult struct {
+	GroupSnapshot GroupSnapshot `json:"groupSnapshot" structs:"groupSnapshot"`
+}
+
+// ModifyGroupSnapshot changes the name, expiration and replication settings of a group snapshot and its members
+func (c *Client) ModifyGroupSnapshot(request ModifyGroupSnapshotRequest) (ModifyGroupSnapshotResult, error) {
+	return c.ModifyGroupSnapshotContext(c.Context(), request)
+}
+
+// ModifyGroupSnapshotContext is like ModifyGroupSnapshot but is canceled with ctx
+func (c *Client) ModifyGroupSnapshotContext(ctx context.Context, request ModifyGroupSnapshotRequest) (ModifyGroupSnapshotResult, error) {
+	var result ModifyGroupSnapshotResult
+	err := c.callMethod(ctx, "ModifyGroupSnapshot", structs.Map(request), &result)
+	return result, err
+}
+
+// DeleteGroupSnapshotRequest holds the parameters of DeleteGroupSnapshot
+type DeleteGroupSnapshotRequest struct {
+	GroupSnapshotID int  `json:"groupSnapshotID" structs:"groupSnapshotID"`
+	SaveMembers     bool `json:"saveMembers" structs:"saveMembers"`
+}
+
+// DeleteGroupSnapshotResult holds the result of DeleteGroupSnapshot
+type DeleteGroupSnapshotResult struct {
+}
+
+// DeleteGroupSnapshot deletes a group snapshot and, unless saveMembers is set, its member snapshots
+func (c *Client) DeleteGroupSnapshot(request DeleteGroupSnapshotRequest) (DeleteGroupSnapshotResult, error) {
+	return c.DeleteGroupSnapshotContext(c.Context(), request)
+}
+
+// DeleteGroupSnapshotContext is like DeleteGroupSnapshot but is canceled with ctx
+func (c *Client) DeleteGroupSnapshotContext(ctx context.Context, request DeleteGroupSnapshotRequest) (DeleteGroupSnapshotResult, error) {
+	var result DeleteGroupSnapshotResult
+	err := c.callMethod(ctx, "DeleteGroupSnapshot", structs.Map(request), &result)
+	return result, err
+}
+
+// RollbackToGroupSnapshotRequest holds the parameters of RollbackToGroupSnapshot
+type RollbackToGroupSnapshotRequest struct {
+	GroupSnapshotID  int         `json:"groupSnapshotID" structs:"groupSnapshotID"`
+	SaveCurrentState bool        `json:"saveCurrentState" structs:"saveCurrentState"`
+	Name             string      `json:"name,omitempty" structs:"name,omitempty"`
+	Attributes       interface{} `json:"attributes,omitempty" structs:"attributes,omitempty"`
+}
+
+// RollbackToGroupSnapshotResult holds the result of RollbackToGroupSnapshot
+type RollbackToGroupSnapshotResult struct {
+	GroupSnapshotID   int                   `json:"groupSnapshotID" structs:"groupSnapshotID"`
+	GroupSnapshotUUID string                `json:"groupSnapshotUUID" structs:"groupSnapshotUUID"`
+	Members           []GroupSnapshotMember `json:"members" structs:"members"`
+}
+
+// RollbackToGroupSnapshot restores the volumes of a group snapshot, optionally saving their current state as a new group snapshot first
+func (c *Client) RollbackToGroupSnapshot(request RollbackToGroupSnapshotRequest) (RollbackToGroupSnapshotResult, error) {
+	return c.RollbackToGroupSnapshotContext(c.Context(), request)
+}
+
+// RollbackToGroupSnapshotContext is like RollbackToGroupSnapshot but is canceled with ctx
+func (c *Client) RollbackToGroupSnapshotContext(ctx context.Context, request RollbackToGroupSnapshotRequest) (RollbackToGroupSnapshotResult, error) {
+	var result RollbackToGroupSnapshotResult
+	err := c.callMethod(ctx, "RollbackToGroupSnapshot", structs.Map(request), &result)
+	return result, err
+}
+
+// CreateInitiatorsRequest holds the parameters of CreateInitiators
+type CreateInitiatorsRequest struct {
+	Initiators []CreateInitiator `json:"initiators" structs:"initiators"`
+}
+
+// CreateInitiatorsResult holds the result of CreateInitiators
+type CreateInitiatorsResult struct {
+	Initiators []Initiator `json:"initiators" structs:"initiators"`
+}
+
+// CreateInitiators creates initiators
+func (c *Client) CreateInitiators(request CreateInitiatorsRequest) (CreateInitiatorsResult, error) {
+	return c.CreateInitiatorsContext(c.Context(), request)
+}
+
+// CreateInitiatorsContext is like CreateInitiators but is canceled with ctx
+func (c *Client) CreateInitiatorsContext(ctx context.Context, request CreateInitiatorsRequest) (CreateInitiatorsResult, error) {
+	var result CreateInitiatorsResult
+	err := c.callMethod(ctx, "CreateInitiators", structs.Map(request), &result)
+	return result, err
+}
+
+// ListInitiatorsRequest holds the parameters of ListInitiators
+type ListInitiatorsRequest struct {
+	StartInitiatorID int   `json:"startInitiatorID,omitempty" structs:"startInitiatorID,omitempty"`
+	Limit            int   `json:"limit,omitempty" structs:"limit,omitempty"`
+	Initiators       []int `json:"initiators,omitempty" structs:"initiators,omitempty"`
+}
+
+// ListInitiatorsResult holds the result of ListInitiators
+type ListInitiatorsResult struct {
+	Initiators []Initiator `json:"initiators" structs:"initiators"`
+}
+
+// ListInitiators returns initiators, optionally filtered by ID
+func (c *Client) ListInitiators(request ListInitiatorsRequest) (ListInitiatorsResult, error) {
+	return c.ListInitiatorsContext(c.Context(), request)
+}
+
+// ListInitiatorsContext is like ListInitiators but is canceled with ctx
+func (c *Client) ListInitiatorsContext(ctx context.Context, request ListInitiatorsRequest) (ListInitiatorsResult, error) {
+	var result ListInitiatorsResult
+	err := c.callMethod(ctx, "ListInitiators", structs.Map(request), &result)
+	return result, err
+}
+
+// ModifyInitiatorsRequest holds the parameters of ModifyInitiators
+type ModifyInitiatorsRequest struct {
+	Initiators []ModifyInitiator `json:"initiators" structs:"initiators"`
+}
+
+// ModifyInitiatorsResult holds the result of ModifyInitiators
+type ModifyInitiatorsResult struct {
+	Initiators []Initiator `json:"initiators" structs:"initiators"`
+}
+
+// ModifyInitiators changes the settings of initiators
+func (c *Client) ModifyInitiators(request ModifyInitiatorsRequest) (ModifyInitiatorsResult, error) {
+	return c.ModifyInitiatorsContext(c.Context(), request)
+}
+
+// ModifyInitiatorsContext is like ModifyInitiators but is canceled with ctx
+func (c *Client) ModifyInitiatorsContext(ctx context.Context, request ModifyInitiatorsRequest) (ModifyInitiatorsResult, error) {
+	var result ModifyInitiatorsResult
+	err := c.callMethod(ctx, "ModifyInitiators", structs.Map(request), &result)
+	return result, err
+}
+
+// DeleteInitiatorsRequest holds the parameters of DeleteInitiators
+type DeleteInitiatorsRequest struct {
+	Initiators []int `json:"initiators" structs:"initiators"`
+}
+
+// DeleteInitiatorsResult holds the result of DeleteInitiators
+type DeleteInitiatorsResult struct {
+}
+
+// DeleteInitiators deletes initiators and removes them from their volume access groups
+func (c *Client) DeleteInitiators(request DeleteInitiatorsRequest) (DeleteInitiatorsResult, error) {
+	return c.DeleteInitiatorsContext(c.Context(), request)
+}
+
+// DeleteInitiatorsContext is like DeleteInitiators but is canceled with ctx
+func (c *Client) DeleteInitiatorsContext(ctx context.Context, request DeleteInitiatorsRequest) (DeleteInitiatorsResult, error) {
+	var result DeleteInitiatorsResult
+	err := c.callMethod(ctx, "DeleteInitiators", structs.Map(request), &result)
+	return result, err
+}
+
+// CreateVolumeAccessGroupRequest holds the parameters of CreateVolumeAccessGroup
+type CreateVolumeAccessGroupRequest struct {
+	Name       string      `json:"name" structs:"name"`
+	Initiators []string    `json:"initiators,omitempty" structs:"initiators,omitempty"`
+	Volumes    []int       `json:"volumes,omitempty" structs:"volumes,omitempty"`
+	Attributes interface{} `json:"attributes,omitempty" structs:"attributes,omitempty"`
+}
+
+// CreateVolumeAccessGroupResult holds the result of CreateVolumeAccessGroup
+type CreateVolumeAccessGroupResult struct {
+	VolumeAccessGroupID int               `json:"volumeAccessGroupID" structs:"volumeAccessGroupID"`
+	VolumeAccessGroup   VolumeAccessGroup `json:"volumeAccessGroup" structs:"volumeAccessGroup"`
+}
+
+// CreateVolumeAccessGroup creates a volume access group
+func (c *Client) CreateVolumeAccessGroup(request CreateVolumeAccessGroupRequest) (CreateVolumeAccessGroupResult, error) {
+	return c.CreateVolumeAccessGroupContext(c.Context(), request)
+}
+
+// CreateVolumeAccessGroupContext is like CreateVolumeAccessGroup but is canceled with ctx
+func (c *Client) CreateVolumeAccessGroupContext(ctx context.Context, request CreateVolumeAccessGroupRequest) (CreateVolumeAccessGroupResult, error) {
+	var result CreateVolumeAccessGroupResult
+	err := c.callMethod(ctx, "CreateVolumeAccessGroup", structs.Map(request), &result)
+	return result, err
+}
+
+// ListVolumeAccessGroupsRequest holds the parameters of ListVolumeAccessGroups
+type ListVolumeAccessGroupsRequest struct {
+	StartVolumeAccessGroupID int   `json:"startVolumeAccessGroupID,omitempty" structs:"startVolumeAccessGroupID,omitempty"`
+	Limit                    int   `json:"limit,omitempty" structs:"limit,omitempty"`
+	VolumeAccessGroups       []int `json:"volumeAccessGroups,omitempty" structs:"volumeAccessGroups,omitempty"`
+}
+
+// ListVolumeAccessGroupsResult holds the result of ListVolumeAccessGroups
+type ListVolumeAccessGroupsResult struct {
+	VolumeAccessGroups         []VolumeAccessGroup `json:"volumeAccessGroups" structs:"volumeAccessGroups"`
+	VolumeAccessGroupsNotFound []int               `json:"volumeAccessGroupsNotFound" structs:"volumeAccessGroupsNotFound"`
+}
+
+// ListVolumeAccessGroups returns volume access groups, optionally filtered by ID
+func (c *Client) ListVolumeAccessGroups(request ListVolumeAccessGroupsRequest) (ListVolumeAccessGroupsResult, error) {
+	return c.ListVolumeAccessGroupsContext(c.Context(), request)
+}
+
+// ListVolumeAccessGroupsContext is like ListVolumeAccessGroups but is canceled with ctx
+func (c *Client) ListVolumeAccessGroupsContext(ctx context.Context, request ListVolumeAccessGroupsRequest) (ListVolumeAccessGroupsResult, error) {
+	var result ListVolumeAccessGroupsResult
+	err := c.callMethod(ctx, "ListVolumeAccessGroups", structs.Map(request), &result)
+	return result, err
+}
+
+// ModifyVolumeAccessGroupRequest holds the parameters of ModifyVolumeAccessGroup
+type ModifyVolumeAccessGroupRequest struct {
+	VolumeAccessGroupID    int         `json:"volumeAccessGroupID" structs:"volumeAccessGroupID"`
+	Name                   string      `json:"name,omitempty" structs:"name,omitempty"`
+	Initiators             []string    `json:"initiators,omitempty" structs:"initiators,omitempty"`
+	Volumes                []int       `json:"volumes,omitempty" structs:"volumes,omitempty"`
+	DeleteOrphanInitiators bool        `json:"deleteOrphanInitiators,omitempty" structs:"deleteOrphanInitiators,omitempty"`
+	Attributes             interface{} `json:"attributes,omitempty" structs:"attributes,omitempty"`
+}
+
+// ModifyVolumeAccessGroupResult holds the result of ModifyVolumeAccessGroup
+type ModifyVolumeAccessGroupResult struct {
+	VolumeAccessGroup VolumeAccessGroup `json:"volumeAccessGroup" structs:"volumeAccessGroup"`
+}
+
+// ModifyVolumeAccessGroup changes the name, initiators or volumes of a volume access group
+func (c *Client) ModifyVolumeAccessGroup(request ModifyVolumeAccessGroupRequest) (ModifyVolumeAccessGroupResult, error) {
+	return c.ModifyVolumeAccessGroupContext(c.Context(), request)
+}
+
+// ModifyVolumeAccessGroupContext is like ModifyVolumeAccessGroup but is canceled with ctx
+func (c *Client) ModifyVolumeAccessGroupContext(ctx context.Context, request ModifyVolumeAccessGroupRequest) (ModifyVolumeAccessGroupResult, error) {
+	var result ModifyVolumeAccessGroupResult
+	err := c.callMethod(ctx, "ModifyVolumeAccessGroup", structs.Map(request), &result)
+	return result, err
+}
+
+// DeleteVolumeAccessGroupRequest holds the parameters of DeleteVolumeAccessGroup
+type DeleteVolumeAccessGroupRequest struct {
+	VolumeAccessGroupID    int  `json:"volumeAccessGroupID" structs:"volumeAccessGroupID"`
+	DeleteOrphanInitiators bool `json:"deleteOrphanInitiators,omitempty" structs:"deleteOrphanInitiators,omitempty"`
+	Force                  bool `json:"force,omitempty" structs:"force,omitempty"`
+}
+
+// DeleteVolumeAccessGroupResult holds the result of DeleteVolumeAccessGroup
+type DeleteVolumeAccessGroupResult struct {
+}
+
+// DeleteVolumeAccessGroup deletes a volume access group
+func (c *Client) DeleteVolumeAccessGroup(request DeleteVolumeAccessGroupRequest) (DeleteVolumeAccessGroupResult, error) {
+	return c.DeleteVolumeAccessGroupContext(c.Context(), request)
+}
+
+// DeleteVolumeAccessGroupContext is like DeleteVolumeAccessGroup but is canceled with ctx
+func (c *Client) DeleteVolumeAccessGroupContext(ctx context.Context, request DeleteVolumeAccessGroupRequest) (DeleteVolumeAccessGroupResult, error) {
+	var result DeleteVolumeAccessGroupResult
+	err := c.callMethod(ctx, "DeleteVolumeAccessGroup", structs.Map(request), &result)
+	return result, err
+}
+
+// ListISCSISessionsRequest holds the parameters of ListISCSISessions
+type ListISCSISessionsRequest struct {
+}
+
+// ListISCSISessionsResult holds the result of ListISCSISessions
+type ListISCSISessionsResult struct {
+	Sessions []ISCSISession `json:"sessions" structs:"sessions"`
+}
+
+// ListISCSISessions returns the active iSCSI sessions of the cluster
+func (c *Client) ListISCSISessions(request ListISCSISessionsRequest) (ListISCSISessionsResult, error) {
+	return c.ListISCSISessionsContext(c.Context(), request)
+}
+
+// ListISCSISessionsContext is like ListISCSISessions but is canceled with ctx
+func (c *Client) ListISCSISessionsContext(ctx context.Context, request ListISCSISessionsRequest) (ListISCSISessionsResult, error) {
+	var result ListISCSISessionsResult
+	err := c.callMethod(ctx, "ListISCSISessions", structs.Map(request), &result)
+	return result, err
+}
+
+// CreateScheduleRequest holds the parameters of CreateSchedule
+type CreateScheduleRequest struct {
+	ScheduleName    string            `json:"scheduleName" structs:"scheduleName"`
+	ScheduleType    string            `json:"scheduleType" structs:"scheduleType"`
+	ScheduleInfo    ScheduleInfo      `json:"scheduleInfo" structs:"scheduleInfo"`
+	Attributes      interface{}       `json:"attributes" structs:"attributes"`
+	Hours           int               `json:"hours" structs:"hours"`
+	Minutes         int               `json:"minutes" structs:"minutes"`
+	Weekdays        []ScheduleWeekday `json:"weekdays,omitempty" structs:"weekdays,omitempty"`
+	Monthdays       []int             `json:"monthdays,omitempty" structs:"monthdays,omitempty"`
+	Paused          bool              `json:"paused" structs:"paused"`
+	Recurring       bool              `json:"recurring" structs:"recurring"`
+	RunNextInterval bool              `json:"runNextInterval" structs:"runNextInterval"`
+	StartingDate    string            `json:"startingDate,omitempty" structs:"startingDate,omitempty"`
+}
+
+// CreateScheduleResult holds the result of CreateSchedule
+type CreateScheduleResult struct {
+	ScheduleID int      `json:"scheduleID" structs:"scheduleID"`
+	Schedule   Schedule `json:"schedule" structs:"schedule"`
+}
+
+// CreateSchedule creates a schedule that snapshots a volume or a group of volumes
+func (c *Client) CreateSchedule(request CreateScheduleRequest) (CreateScheduleResult, error) {
+	return c.CreateScheduleContext(c.Context(), request)
+}
+
+// CreateScheduleContext is like CreateSchedule but is canceled with ctx
+func (c *Client) CreateScheduleContext(ctx context.Context, request CreateScheduleRequest) (CreateScheduleResult, error) {
+	var result CreateScheduleResult
+	err := c.callMethod(ctx, "CreateSchedule", structs.Map(request), &result)
+	return result, err
+}
+
+// ListSchedulesRequest holds the parameters of ListSchedules
+type ListSchedulesRequest struct {
+}
+
+// ListSchedulesResult holds the result of ListSchedules
+type ListSchedulesResult struct {
+	Schedules []Schedule `json:"schedules" structs:"schedules"`
+}
+
+// ListSchedules returns all schedules
+func (c *Client) ListSchedules(request ListSchedulesRequest) (ListSchedulesResult, error) {
+	return c.ListSchedulesContext(c.Context(), request)
+}
+
+// ListSchedulesContext is like ListSchedules but is canceled with ctx
+func (c *Client) ListSchedulesContext(ctx context.Context, request ListSchedulesRequest) (ListSchedulesResult, error) {
+	var result ListSchedulesResult
+	err := c.callMethod(ctx, "ListSchedules", structs.Map(request), &result)
+	return result, err
+}
+
+// GetScheduleRequest holds the parameters of GetSchedule
+type GetScheduleRequest struct {
+	ScheduleID int `json:"scheduleID" structs:"scheduleID"`
+}
+
+// GetScheduleResult holds the result of GetSchedule
+type GetScheduleResult struct {
+	Schedule Schedule `json:"schedule" structs:"schedule"`
+}
+
+// GetSchedule returns a schedule
+func (c *Client) GetSchedule(request GetScheduleRequest) (GetScheduleResult, error) {
+	return c.GetScheduleContext(c.Context(), request)
+}
+
+// GetScheduleContext is like GetSchedule but is canceled with ctx
+func (c *Client) GetScheduleContext(ctx context.Context, request GetScheduleRequest) (GetScheduleResult, error) {
+	var result GetScheduleResult
+	err := c.callMethod(ctx, "GetSchedule", structs.Map(request), &result)
+	return result, err
+}
+
+// ModifyScheduleRequest holds the parameters of ModifySchedule
+type ModifyScheduleRequest struct {
+	ScheduleID      int               `json:"scheduleID" structs:"scheduleID"`
+	ScheduleName    string            `json:"scheduleName,omitempty" structs:"scheduleName,omitempty"`
+	ScheduleInfo    *ScheduleInfo     `json:"scheduleInfo,omitempty" structs:"scheduleInfo,omitempty"`
+	Attributes      interface{}       `json:"attributes,omitempty" structs:"attributes,omitempty"`
+	Hours           *int              `json:"hours,omitempty" structs:"hours,omitempty"`
+	Minutes         *int              `json:"minutes,omitempty" structs:"minutes,omitempty"`
+	Weekdays        []ScheduleWeekday `json:"weekdays,omitempty" structs:"weekdays,omitempty"`
+	Monthdays       []int             `json:"monthdays,omitempty" structs:"monthdays,omitempty"`
+	Paused          *bool             `json:"paused,omitempty" structs:"paused,omitempty"`
+	Recurring       *bool             `json:"recurring,omitempty" structs:"recurring,omitempty"`
+	RunNextInterval *bool             `json:"runNextInterval,omitempty" structs:"runNextInterval,omitempty"`
+	StartingDate    string            `json:"startingDate,omitempty" structs:"startingDate,omitempty"`
+	ToBeDeleted     *bool             `json:"toBeDeleted,omitempty" structs:"toBeDeleted,omitempty"`
+}
+
+// ModifyScheduleResult holds the result of ModifySchedule
+type ModifyScheduleResult struct {
+	Schedule Schedule `json:"schedule" structs:"schedule"`
+}
+
+// ModifySchedule changes a schedule. Schedules are deleted by setting toBeDeleted.
+func (c *Client) ModifySchedule(request ModifyScheduleRequest) (ModifyScheduleResult, error) {
+	return c.ModifyScheduleContext(c.Context(), request)
+}
+
+// ModifyScheduleContext is like ModifySchedule but is canceled with ctx
+func (c *Client) ModifyScheduleContext(ctx context.Context, request ModifyScheduleRequest) (ModifyScheduleResult, error) {
+	var result ModifyScheduleResult
+	err := c.callMethod(ctx, "ModifySchedule", structs.Map(request), &result)
+	return result, err
+}
+
+// CreateQoSPolicyRequest holds the parameters of CreateQoSPolicy
+type CreateQoSPolicyRequest struct {
+	Name string `json:"name" structs:"name"`
+	QoS  QoS    `json:"qos" structs:"qos"`
+}
+
+// CreateQoSPolicyResult holds the result of CreateQoSPolicy
+type CreateQoSPolicyResult struct {
+	QoSPolicy QoSPolicy `json:"qosPolicy" structs:"qosPolicy"`
+}
+
+// CreateQoSPolicy creates a QoS policy that volumes can be associated with
+func (c *Client) CreateQoSPolicy(request CreateQoSPolicyRequest) (CreateQoSPolicyResult, error) {
+	return c.CreateQoSPolicyContext(c.Context(), request)
+}
+
+// CreateQoSPolicyContext is like CreateQoSPolicy but is canceled with ctx
+func (c *Client) CreateQoSPolicyContext(ctx context.Context, request CreateQoSPolicyRequest) (CreateQoSPolicyResult, error) {
+	var result CreateQoSPolicyResult
+	err := c.callMethod(ctx, "CreateQoSPolicy", structs.Map(request), &result)
+	return result, err
+}
+
+// ListQoSPoliciesRequest holds the parameters of ListQoSPolicies
+type ListQoSPoliciesRequest struct {
+}
+
+// ListQoSPoliciesResult holds the result of ListQoSPolicies
+type ListQoSPoliciesResult struct {
+	QoSPolicies []QoSPolicy `json:"qosPolicies" structs:"qosPolicies"`
+}
+
+// ListQoSPolicies returns all QoS policies
+func (c *Client) ListQoSPolicies(request ListQoSPoliciesRequest) (ListQoSPoliciesResult, error) {
+	return c.ListQoSPoliciesContext(c.Context(), request)
+}
+
+// ListQoSPoliciesContext is like ListQoSPolicies but is canceled with ctx
+func (c *Client) ListQoSPoliciesContext(ctx context.Context, request ListQoSPoliciesRequest) (ListQoSPoliciesResult, error) {
+	var result ListQoSPoliciesResult
+	err := c.callMethod(ctx, "ListQoSPolicies", structs.Map(request), &result)
+	return result, err
+}
+
+// ModifyQoSPolicyRequest holds the parameters of ModifyQoSPolicy
+type ModifyQoSPolicyRequest struct {
+	QoSPolicyID int    `json:"qosPolicyID" structs:"qosPolicyID"`
+	Name        string `json:"name,omitempty" structs:"name,omitempty"`
+	QoS         *QoS   `json:"qos,omitempty" structs:"qos,omitempty"`
+}
+
+// ModifyQoSPolicyResult holds the result of ModifyQoSPolicy
+type ModifyQoSPolicyResult struct {
+	QoSPolicy QoSPolicy `json:"qosPolicy" structs:"qosPolicy"`
+}
+
+// ModifyQoSPolicy changes a QoS policy and the QoS of all volumes associated with it
+func (c *Client) ModifyQoSPolicy(request ModifyQoSPolicyRequest) (ModifyQoSPolicyResult, error) {
+	return c.ModifyQoSPolicyContext(c.Context(), request)
+}
+
+// ModifyQoSPolicyContext is like ModifyQoSPolicy but is canceled with ctx
+func (c *Client) ModifyQoSPolicyContext(ctx context.Context, request ModifyQoSPolicyRequest) (ModifyQoSPolicyResult, error) {
+	var result ModifyQoSPolicyResult
+	err := c.callMethod(ctx, "ModifyQoSPolicy", structs.Map(request), &result)
+	return result, err
+}
+
+// DeleteQoSPolicyRequest holds the parameters of DeleteQoSPolicy
+type DeleteQoSPolicyRequest struct {
+	QoSPolicyID int `json:"qosPolicyID" structs:"qosPolicyID"`
+}
+
+// DeleteQoSPolicyResult holds the result of DeleteQoSPolicy
+type DeleteQoSPolicyResult struct {
+}
+
+// DeleteQoSPolicy deletes a QoS policy that no volume is associated with
+func (c *Client) DeleteQoSPolicy(request DeleteQoSPolicyRequest) (DeleteQoSPolicyResult, error) {
+	return c.DeleteQoSPolicyContext(c.Context(), request)
+}
+
+// DeleteQoSPolicyContext is like DeleteQoSPolicy but is canceled with ctx
+func (c *Client) DeleteQoSPolicyContext(ctx context.Context, request DeleteQoSPolicyRequest) (DeleteQoSPolicyResult, error) {
+	var result DeleteQoSPolicyResult
+	err := c.callMethod(ctx, "DeleteQoSPolicy", structs.Map(request), &result)
+	return result, err
+}
+
+// GetLimitsRequest holds the parameters of GetLimits
+type GetLimitsRequest struct {
+}
+
+// GetLimitsResult holds the result of GetLimits
+type GetLimitsResult struct {
+	VolumeMinIOPSMin   int `json:"volumeMinIOPSMin" structs:"volumeMinIOPSMin"`
+	VolumeMinIOPSMax   int `json:"volumeMinIOPSMax" structs:"volumeMinIOPSMax"`
+	VolumeMaxIOPSMin   int `json:"volumeMaxIOPSMin" structs:"volumeMaxIOPSMin"`
+	VolumeMaxIOPSMax   int `json:"volumeMaxIOPSMax" structs:"volumeMaxIOPSMax"`
+	VolumeBurstIOPSMin int `json:"volumeBurstIOPSMin" structs:"volumeBurstIOPSMin"`
+	VolumeBurstIOPSMax int `json:"volumeBurstIOPSMax" structs:"volumeBurstIOPSMax"`
+	VolumeSizeMin      int `json:"volumeSizeMin" structs:"volumeSizeMin"`
+	VolumeSizeMax      int `json:"volumeSizeMax" structs:"volumeSizeMax"`
+	SecretLengthMin    int `json:"secretLengthMin" structs:"secretLengthMin"`
+	SecretLengthMax    int `json:"secretLengthMax" structs:"secretLengthMax"`
+}
+
+// GetLimits returns the limits the cluster enforces on objects and parameters
+func (c *Client) GetLimits(request GetLimitsRequest) (GetLimitsResult, error) {
+	return c.GetLimitsContext(c.Context(), request)
+}
+
+// GetLimitsContext is like GetLimits but is canceled with ctx
+func (c *Client) GetLimitsContext(ctx context.Context, request GetLimitsRequest) (GetLimitsResult, error) {
+	var result GetLimitsResult
+	err := c.callMethod(ctx, "GetLimits", structs.Map(request), &result)
+	return result, err
+}
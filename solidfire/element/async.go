@@ -0,0 +1,132 @@
+package element
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultAsyncPollInterval    = 2 * time.Second
+	defaultAsyncMaxPollInterval = 30 * time.Second
+	defaultAsyncTimeout         = 60 * time.Minute
+)
+
+// AsyncResultError is returned when the cluster reports that a long-running operation failed
+type AsyncResultError struct {
+	AsyncHandle int
+	Name        string
+	Message     string
+}
+
+func (e *AsyncResultError) Error() string {
+	return fmt.Sprintf("Async operation %v failed. %v: %v", e.AsyncHandle, e.Name, e.Message)
+}
+
+// WaitForAsyncResult polls the cluster until the operation identified by handle has finished and decodes
+// its result into result, which may be nil if the caller does not need it. Polling starts at the client's
+// AsyncPollInterval and backs off to AsyncMaxPollInterval; AsyncTimeout bounds the overall wait.
+func (c *Client) WaitForAsyncResult(handle int, result interface{}) error {
+	return c.WaitForAsyncResultContext(c.Context(), handle, result)
+}
+
+// WaitForAsyncResultContext is like WaitForAsyncResult but stops waiting when ctx is done. The operation
+// itself keeps running on the cluster.
+func (c *Client) WaitForAsyncResultContext(ctx context.Context, handle int, result interface{}) error {
+	interval, maxInterval, timeout := c.asyncPollSettings()
+	deadline := time.Now().Add(timeout)
+	started := time.Now()
+
+	for {
+		res, err := c.GetAsyncResultContext(ctx, GetAsyncResultRequest{AsyncHandle: handle, KeepResult: true})
+		if err != nil {
+			return err
+		}
+
+		if res.Status == "complete" {
+			// Polls keep the result in case a response is lost after the operation completes, so it is
+			// released separately once it has been read
+			c.releaseAsyncResult(ctx, handle)
+			if res.Error != nil {
+				return &AsyncResultError{AsyncHandle: handle, Name: res.Error.Name, Message: res.Error.Message}
+			}
+			log.WithFields(logrus.Fields{
+				"asyncHandle": handle,
+				"resultType":  res.ResultType,
+				"elapsed":     time.Since(started).String(),
+			}).Info("Async operation complete")
+
+			if result == nil || res.Result == nil {
+				return nil
+			}
+			if err := json.Unmarshal([]byte(*res.Result), result); err != nil {
+				log.Printf("Failed to unmarshal result of async operation %v", handle)
+				return err
+			}
+			return nil
+		}
+
+		fields := logrus.Fields{
+			"asyncHandle": handle,
+			"resultType":  res.ResultType,
+			"status":      res.Status,
+			"elapsed":     time.Since(started).String(),
+		}
+		if res.Details != nil {
+			fields["details"] = string(*res.Details)
+		}
+		log.WithFields(fields).Info("Waiting for async operation")
+
+		if time.Now().Add(interval).After(deadline) {
+			return fmt.Errorf("Timed out after %v waiting for async operation %v to complete", timeout, handle)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return newAPIError("GetAsyncResult", ctx.Err())
+		}
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+// releaseAsyncResult fetches the result of a finished operation without keeping it, so that finished operations
+// do not pile up on the cluster. A retried fetch finds the result already discarded, so errors are only logged.
+func (c *Client) releaseAsyncResult(ctx context.Context, handle int) {
+	if _, err := c.GetAsyncResultContext(ctx, GetAsyncResultRequest{AsyncHandle: handle}); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			log.Printf("Result of async operation %v was already released", handle)
+			return
+		}
+		log.Printf("[WARN] Unable to release the result of async operation %v: %v", handle, err)
+	}
+}
+
+func (c *Client) asyncPollSettings() (interval, maxInterval, timeout time.Duration) {
+	interval = c.AsyncPollInterval
+	if interval <= 0 {
+		interval = defaultAsyncPollInterval
+	}
+	maxInterval = c.AsyncMaxPollInterval
+	if maxInterval < interval {
+		maxInterval = defaultAsyncMaxPollInterval
+		if maxInterval < interval {
+			maxInterval = interval
+		}
+	}
+	timeout = c.AsyncTimeout
+	if timeout <= 0 {
+		timeout = defaultAsyncTimeout
+	}
+	return interval, maxInterval, timeout
+}
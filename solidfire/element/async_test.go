@@ -0,0 +1,168 @@
+package element
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestWaitForAsyncResult(t *testing.T) {
+	defer gock.Off()
+
+	fakeHost := "http://fakehost"
+
+	gock.New(fakeHost).
+		Post("/json-rpc/1.0").
+		BodyString(`"keepResult":true`).
+		Reply(http.StatusOK).
+		JSON(map[string]interface{}{
+			"result": map[string]interface{}{
+				"status":     "running",
+				"resultType": "Clone",
+				"details":    map[string]interface{}{"volumeID": 12},
+			},
+		})
+	gock.New(fakeHost).
+		Post("/json-rpc/1.0").
+		BodyString(`"keepResult":true`).
+		Reply(http.StatusOK).
+		JSON(map[string]interface{}{
+			"result": map[string]interface{}{
+				"status":     "complete",
+				"resultType": "Clone",
+				"result":     map[string]interface{}{"volumeID": 12, "cloneID": 3},
+			},
+		})
+	// The result is fetched once more without keeping it, to release it on the cluster
+	gock.New(fakeHost).
+		Post("/json-rpc/1.0").
+		BodyString(`"keepResult":false`).
+		Reply(http.StatusOK).
+		JSON(map[string]interface{}{
+			"result": map[string]interface{}{
+				"status":     "complete",
+				"resultType": "Clone",
+				"result":     map[string]interface{}{"volumeID": 12, "cloneID": 3},
+			},
+		})
+
+	client := &Client{
+		Host:              fakeHost,
+		AsyncPollInterval: time.Millisecond,
+	}
+
+	var result struct {
+		VolumeID int `json:"volumeID"`
+		CloneID  int `json:"cloneID"`
+	}
+	if err := client.WaitForAsyncResult(7, &result); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 12, result.VolumeID)
+	assert.Equal(t, 3, result.CloneID)
+	assert.True(t, gock.IsDone())
+}
+
+func TestWaitForAsyncResultReleaseFailure(t *testing.T) {
+	defer gock.Off()
+
+	fakeHost := "http://fakehost"
+
+	gock.New(fakeHost).
+		Post("/json-rpc/1.0").
+		BodyString(`"keepResult":true`).
+		Reply(http.StatusOK).
+		JSON(map[string]interface{}{
+			"result": map[string]interface{}{
+				"status":     "complete",
+				"resultType": "Clone",
+				"result":     map[string]interface{}{"volumeID": 12, "cloneID": 3},
+			},
+		})
+	// A retried release finds that the cluster has already discarded the result
+	gock.New(fakeHost).
+		Post("/json-rpc/1.0").
+		BodyString(`"keepResult":false`).
+		Reply(http.StatusOK).
+		JSON(map[string]interface{}{
+			"error": map[string]interface{}{
+				"code":    500,
+				"name":    "xUnknownAsyncHandle",
+				"message": "Async handle 7 does not exist",
+			},
+		})
+
+	client := &Client{
+		Host:              fakeHost,
+		AsyncPollInterval: time.Millisecond,
+	}
+
+	var result struct {
+		VolumeID int `json:"volumeID"`
+	}
+	if err := client.WaitForAsyncResult(7, &result); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 12, result.VolumeID)
+	assert.True(t, gock.IsDone())
+}
+
+func TestWaitForAsyncResultFailure(t *testing.T) {
+	defer gock.Off()
+
+	fakeHost := "http://fakehost"
+
+	gock.New(fakeHost).
+		Post("/json-rpc/1.0").
+		Times(2).
+		Reply(http.StatusOK).
+		JSON(map[string]interface{}{
+			"result": map[string]interface{}{
+				"status": "complete",
+				"error": map[string]interface{}{
+					"name":    "xNotEnoughSpace",
+					"message": "not enough space",
+				},
+			},
+		})
+
+	client := &Client{
+		Host:              fakeHost,
+		AsyncPollInterval: time.Millisecond,
+	}
+
+	err := client.WaitForAsyncResult(7, nil)
+	if assert.IsType(t, &AsyncResultError{}, err) {
+		assert.Equal(t, 7, err.(*AsyncResultError).AsyncHandle)
+	}
+	assert.True(t, gock.IsDone())
+}
+
+func TestWaitForAsyncResultTimeout(t *testing.T) {
+	defer gock.Off()
+
+	fakeHost := "http://fakehost"
+
+	gock.New(fakeHost).
+		Post("/json-rpc/1.0").
+		Persist().
+		Reply(http.StatusOK).
+		JSON(map[string]interface{}{
+			"result": map[string]interface{}{
+				"status": "running",
+			},
+		})
+
+	client := &Client{
+		Host:              fakeHost,
+		AsyncPollInterval: time.Millisecond,
+		AsyncTimeout:      10 * time.Millisecond,
+	}
+
+	assert.Error(t, client.WaitForAsyncResult(7, nil))
+}
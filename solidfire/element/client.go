@@ -1,9 +1,13 @@
+//go:generate go run ./internal/apigen -in api.json -out api_generated.go
+
 package element
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/solidfire/terraform-provider-solidfire/solidfire/element/jsonrpc"
@@ -25,36 +29,72 @@ type Client struct {
 	Password              string
 	MaxConcurrentRequests int
 	HTTPTransport         http.RoundTripper
+	RetryPolicy           *jsonrpc.RetryPolicy
+	AsyncPollInterval     time.Duration
+	AsyncMaxPollInterval  time.Duration
+	AsyncTimeout          time.Duration
 
 	apiVersion string
+	ctx        context.Context
 
 	initOnce      sync.Once
 	jsonrpcClient *jsonrpc.Client
 	requestSlots  chan int
+
+	limitsMutex sync.Mutex
+	limits      *GetLimitsResult
 }
 
-// CallAPIMethod can be used to make a request to any Element API method, receiving results as raw JSON
+// CallAPIMethod can be used to make a request to any Element API method, receiving results as raw JSON.
+// The request is made with the client's base context, see SetContext.
 func (c *Client) CallAPIMethod(method string, params map[string]interface{}) (*json.RawMessage, error) {
+	return c.CallAPIMethodContext(c.Context(), method, params)
+}
+
+// CallAPIMethodContext is like CallAPIMethod but aborts the request, including any wait for a free
+// request slot or between retries, when ctx is canceled or its deadline passes
+func (c *Client) CallAPIMethodContext(ctx context.Context, method string, params map[string]interface{}) (*json.RawMessage, error) {
+	return c.callAPIMethodAtVersion(ctx, c.GetAPIVersion(), method, params)
+}
+
+// callMethod calls method with params and decodes its result into result. It backs the generated typed methods.
+func (c *Client) callMethod(ctx context.Context, method string, params map[string]interface{}, result interface{}) error {
+	response, err := c.CallAPIMethodContext(ctx, method, params)
+	if err != nil {
+		log.Printf("%v request failed", method)
+		return err
+	}
+
+	if err := json.Unmarshal([]byte(*response), result); err != nil {
+		log.Printf("Failed to unmarshal response from %v", method)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) callAPIMethodAtVersion(ctx context.Context, apiVersion string, method string, params map[string]interface{}) (*json.RawMessage, error) {
 	c.initOnce.Do(c.init)
 
-	c.waitForAvailableSlot()
+	if err := c.waitForAvailableSlot(ctx); err != nil {
+		return nil, newAPIError(method, err)
+	}
 	defer c.releaseSlot()
 
 	log.WithFields(logrus.Fields{
 		"method": method,
-		"params": params,
+		"params": Redact(params),
 	}).Debug("Calling API")
 
 	if params == nil {
 		params = map[string]interface{}{}
 	}
-	result, err := c.jsonrpcClient.Do(&jsonrpc.Request{
-		BaseURL: "/json-rpc/" + c.GetAPIVersion(),
+	result, err := c.jsonrpcClient.DoContext(ctx, &jsonrpc.Request{
+		BaseURL: "/json-rpc/" + apiVersion,
 		Method:  method,
 		Params:  params,
 	})
 	if err != nil {
-		return nil, err
+		return nil, newAPIError(method, err)
 	}
 	log.WithFields(logrus.Fields{
 		"method": method,
@@ -72,6 +112,7 @@ func (c *Client) init() {
 		Username:      c.Username,
 		Password:      c.Password,
 		HTTPTransport: c.HTTPTransport,
+		RetryPolicy:   c.RetryPolicy,
 	}
 }
 
@@ -80,7 +121,22 @@ func (c *Client) SetAPIVersion(apiVersion string) {
 	c.apiVersion = apiVersion
 }
 
-// GetAPIVersion returns the API version that will be used for Element API requests
+// SetContext sets the base context used by the methods that do not take a context. Canceling it aborts
+// all in-flight requests made through those methods.
+func (c *Client) SetContext(ctx context.Context) {
+	c.ctx = ctx
+}
+
+// Context returns the client's base context
+func (c *Client) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// GetAPIVersion returns the API version that will be used for Element API requests. Until a version
+// is set or negotiated with NegotiateAPIVersion, requests are made against version 1.0.
 func (c *Client) GetAPIVersion() string {
 	if c.apiVersion == "" {
 		return "1.0"
@@ -88,8 +144,13 @@ func (c *Client) GetAPIVersion() string {
 	return c.apiVersion
 }
 
-func (c *Client) waitForAvailableSlot() {
-	c.requestSlots <- 1
+func (c *Client) waitForAvailableSlot(ctx context.Context) error {
+	select {
+	case c.requestSlots <- 1:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 func (c *Client) releaseSlot() {
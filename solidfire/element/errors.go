@@ -0,0 +1,196 @@
+package element
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element/jsonrpc"
+)
+
+// Error classes returned by the client. Use errors.Is to test an error returned by any client method
+// against one of these, or errors.As with *APIError to get at the method and Element error name.
+var (
+	ErrNotFound         = errors.New("object not found")
+	ErrAlreadyExists    = errors.New("object already exists")
+	ErrInUse            = errors.New("object in use")
+	ErrLimitExceeded    = errors.New("limit exceeded")
+	ErrUnauthorized     = errors.New("unauthorized")
+	ErrInvalidParameter = errors.New("invalid parameter")
+	ErrUnsupported      = errors.New("unsupported by cluster")
+	ErrTransient        = errors.New("transient failure")
+	ErrCanceled         = errors.New("request canceled")
+	ErrUnknown          = errors.New("unclassified error")
+)
+
+// errorClasses maps Element API error names to an error class. Names missing from the catalogue
+// are classified by naming convention in classifyErrorName.
+var errorClasses = map[string]error{
+	"xUnknown":                         ErrNotFound,
+	"xUnknownAccount":                  ErrNotFound,
+	"xUnknownAsyncHandle":              ErrNotFound,
+	"xAccountIDDoesNotExist":           ErrNotFound,
+	"xVolumeIDDoesNotExist":            ErrNotFound,
+	"xVolumeDoesNotExist":              ErrNotFound,
+	"xVolumeAccessGroupIDDoesNotExist": ErrNotFound,
+	"xInitiatorDoesNotExist":           ErrNotFound,
+	"xInitiatorIDDoesNotExist":         ErrNotFound,
+	"xSnapshotIDDoesNotExist":          ErrNotFound,
+	"xGroupSnapshotIDDoesNotExist":     ErrNotFound,
+	"xScheduleIDDoesNotExist":          ErrNotFound,
+	"xQoSPolicyDoesNotExist":           ErrNotFound,
+	"xAsyncHandleNotFound":             ErrNotFound,
+
+	"xDuplicateUsername":            ErrAlreadyExists,
+	"xAccountAlreadyExists":         ErrAlreadyExists,
+	"xInitiatorExists":              ErrAlreadyExists,
+	"xVolumeAccessGroupExists":      ErrAlreadyExists,
+	"xVolumeAccessGroupNameExists":  ErrAlreadyExists,
+	"xDuplicateVolumeAccessGroupID": ErrAlreadyExists,
+	"xQoSPolicyNameExists":          ErrAlreadyExists,
+
+	"xAccountHasVolumes":           ErrInUse,
+	"xAccountInUse":                ErrInUse,
+	"xVolumeInUse":                 ErrInUse,
+	"xVolumeAccessGroupInUse":      ErrInUse,
+	"xVolumesInVolumeAccessGroup":  ErrInUse,
+	"xInitiatorInUse":              ErrInUse,
+	"xSnapshotInUse":               ErrInUse,
+	"xQoSPolicyInUse":              ErrInUse,
+	"xVolumeAlreadyInAccessGroup":  ErrInUse,
+	"xVolumePairingAlreadyExists":  ErrInUse,
+	"xCloneAlreadyInProgress":      ErrInUse,
+	"xBulkVolumeJobAlreadyRunning": ErrInUse,
+
+	"xExceededLimit":                    ErrLimitExceeded,
+	"xMaxVolumesExceeded":               ErrLimitExceeded,
+	"xMaxVolumesPerAccountExceeded":     ErrLimitExceeded,
+	"xMaxVolumesPerAccessGroupExceeded": ErrLimitExceeded,
+	"xMaxInitiatorsExceeded":            ErrLimitExceeded,
+	"xMaxSnapshotsPerVolumeExceeded":    ErrLimitExceeded,
+	"xMaxAccountsExceeded":              ErrLimitExceeded,
+	"xMaxClonesExceeded":                ErrLimitExceeded,
+	"xNotEnoughSpace":                   ErrLimitExceeded,
+	"xSliceLimitExceeded":               ErrLimitExceeded,
+
+	"xPermissionDenied":    ErrUnauthorized,
+	"xNotAuthorized":       ErrUnauthorized,
+	"xAuthenticationError": ErrUnauthorized,
+	"xInvalidCredentials":  ErrUnauthorized,
+
+	"xInvalidParameter":         ErrInvalidParameter,
+	"xInvalidParameterType":     ErrInvalidParameter,
+	"xMissingParameter":         ErrInvalidParameter,
+	"xInvalidAPIParameter":      ErrInvalidParameter,
+	"xParameterOutOfRange":      ErrInvalidParameter,
+	"xInvalidQoS":               ErrInvalidParameter,
+	"xVolumeShrinkProhibited":   ErrInvalidParameter,
+	"xInvalidSecretLength":      ErrInvalidParameter,
+	"xInvalidInitiatorName":     ErrInvalidParameter,
+	"xInvalidVolumeAccessGroup": ErrInvalidParameter,
+
+	"xUnknownAPIMethod":      ErrUnsupported,
+	"xUnknownAPIVersion":     ErrUnsupported,
+	"xUnsupportedAPIVersion": ErrUnsupported,
+	"xNotImplemented":        ErrUnsupported,
+}
+
+func init() {
+	for _, name := range jsonrpc.DefaultRetryableErrorNames {
+		errorClasses[name] = ErrTransient
+	}
+}
+
+// APIError is returned by client methods when a call to the Element API fails
+type APIError struct {
+	// Method is the Element API method that was called
+	Method string
+	// Name is the Element error name, such as xUnknownAccount, if the cluster returned one
+	Name string
+	// Class is one of the Err* error classes
+	Class error
+	// Err is the underlying error
+	Err error
+}
+
+func (e *APIError) Error() string {
+	if e.Class == ErrCanceled {
+		return fmt.Sprintf("%v was canceled before it completed: %v", e.Method, e.Err)
+	}
+	return fmt.Sprintf("%v failed: %v", e.Method, e.Err)
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches the error against its class, so errors.Is(err, element.ErrNotFound) works
+func (e *APIError) Is(target error) bool {
+	return e.Class == target
+}
+
+// newAPIError classifies an error returned by the jsonrpc client
+func newAPIError(method string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	apiErr := &APIError{Method: method, Err: err}
+
+	var responseErr *jsonrpc.ResponseError
+	var statusErr *jsonrpc.StatusError
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		apiErr.Class = ErrCanceled
+	case errors.As(err, &responseErr):
+		apiErr.Name = responseErr.Name
+		apiErr.Class = classifyErrorName(responseErr.Name)
+	case errors.As(err, &statusErr):
+		apiErr.Class = classifyStatusCode(statusErr.StatusCode)
+	case jsonrpc.IsNetworkError(err):
+		apiErr.Class = ErrTransient
+	default:
+		apiErr.Class = ErrUnknown
+	}
+
+	return apiErr
+}
+
+// newNotFoundError is used by lookups that find no matching object in an otherwise successful response
+func newNotFoundError(method string, format string, args ...interface{}) error {
+	return &APIError{Method: method, Class: ErrNotFound, Err: fmt.Errorf(format, args...)}
+}
+
+func classifyErrorName(name string) error {
+	if class, ok := errorClasses[name]; ok {
+		return class
+	}
+
+	switch {
+	case strings.HasSuffix(name, "DoesNotExist"), strings.HasSuffix(name, "NotFound"), strings.HasPrefix(name, "xUnknown"):
+		return ErrNotFound
+	case strings.HasSuffix(name, "AlreadyExists"), strings.HasSuffix(name, "Exists"), strings.HasPrefix(name, "xDuplicate"):
+		return ErrAlreadyExists
+	case strings.HasSuffix(name, "InUse"):
+		return ErrInUse
+	case strings.Contains(name, "Exceeded"), strings.Contains(name, "TooMany"):
+		return ErrLimitExceeded
+	case strings.HasPrefix(name, "xInvalid"), strings.HasPrefix(name, "xMissing"):
+		return ErrInvalidParameter
+	}
+
+	return ErrUnknown
+}
+
+func classifyStatusCode(code int) error {
+	switch {
+	case code == 401 || code == 403:
+		return ErrUnauthorized
+	case code == 404:
+		return ErrUnsupported
+	case code == 429 || code >= 500:
+		return ErrTransient
+	}
+	return ErrUnknown
+}
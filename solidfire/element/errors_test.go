@@ -0,0 +1,71 @@
+package element
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element/jsonrpc"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestClassifyErrorName(t *testing.T) {
+	cases := map[string]error{
+		"xUnknownAccount":         ErrNotFound,
+		"xVolumeIDDoesNotExist":   ErrNotFound,
+		"xSomethingNewNotFound":   ErrNotFound,
+		"xDuplicateUsername":      ErrAlreadyExists,
+		"xAccountHasVolumes":      ErrInUse,
+		"xMaxVolumesExceeded":     ErrLimitExceeded,
+		"xPermissionDenied":       ErrUnauthorized,
+		"xInvalidParameter":       ErrInvalidParameter,
+		"xUnknownAPIMethod":       ErrUnsupported,
+		"xNotPrimary":             ErrTransient,
+		"xDBConnectionLost":       ErrTransient,
+		"xSomethingCompletelyNew": ErrUnknown,
+	}
+
+	for name, expected := range cases {
+		assert.Equal(t, expected, classifyErrorName(name), name)
+	}
+}
+
+func TestAPIErrorIsAndAs(t *testing.T) {
+	err := newAPIError("GetAccountByID", &jsonrpc.ResponseError{Code: 500, Name: "xUnknownAccount"})
+
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.False(t, errors.Is(err, ErrTransient))
+
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, "GetAccountByID", apiErr.Method)
+		assert.Equal(t, "xUnknownAccount", apiErr.Name)
+	}
+
+	var responseErr *jsonrpc.ResponseError
+	assert.True(t, errors.As(err, &responseErr))
+
+	assert.True(t, errors.Is(newAPIError("ListVolumes", &jsonrpc.StatusError{StatusCode: 401}), ErrUnauthorized))
+	assert.True(t, errors.Is(newAPIError("ListVolumes", &jsonrpc.StatusError{StatusCode: 503}), ErrTransient))
+}
+
+func TestGetVolumeByIDNotFound(t *testing.T) {
+	defer gock.Off()
+
+	fakeHost := "http://fakehost"
+
+	gock.New(fakeHost).
+		Post("/json-rpc/1.0").
+		Reply(http.StatusOK).
+		JSON(map[string]interface{}{
+			"result": map[string]interface{}{
+				"volumes": []interface{}{},
+			},
+		})
+
+	client := &Client{Host: fakeHost}
+
+	_, err := client.GetVolumeByID("42")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
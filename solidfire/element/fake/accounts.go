@@ -0,0 +1,284 @@
+package fake
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
+	"sort"
+)
+
+func init() {
+	methods["AddAccount"] = addAccount
+	methods["GetAccountByID"] = getAccountByID
+	methods["GetAccountByName"] = getAccountByName
+	methods["ListAccounts"] = listAccounts
+	methods["ModifyAccount"] = modifyAccount
+	methods["RemoveAccount"] = removeAccount
+}
+
+// Account is an Element account as returned by the API
+type Account struct {
+	AccountID          int                    `json:"accountID"`
+	Username           string                 `json:"username"`
+	Status             string                 `json:"status"`
+	InitiatorSecret    string                 `json:"initiatorSecret"`
+	TargetSecret       string                 `json:"targetSecret"`
+	EnableChap         bool                   `json:"enableChap"`
+	Volumes            []int                  `json:"volumes"`
+	StorageContainerID string                 `json:"storageContainerID"`
+	Attributes         map[string]interface{} `json:"attributes"`
+}
+
+// noStorageContainerID is the storage container ID the cluster reports for accounts without a storage container.
+// Accounts only get a storage container when they own virtual volumes, which the fake does not model.
+const noStorageContainerID = "00000000-0000-0000-0000-000000000000"
+
+const (
+	secretLengthMin  = 12
+	secretLengthMax  = 16
+	secretCharacters = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+)
+
+func generateSecret() string {
+	secret := make([]byte, secretLengthMin)
+	for i := range secret {
+		n, _ := rand.Int(rand.Reader, big.NewInt(int64(len(secretCharacters))))
+		secret[i] = secretCharacters[n.Int64()]
+	}
+	return string(secret)
+}
+
+func validateSecret(field string, secret string) error {
+	if len(secret) < secretLengthMin || len(secret) > secretLengthMax {
+		return newError("xInvalidParameter", "%v must be %v to %v characters long", field, secretLengthMin, secretLengthMax)
+	}
+	return nil
+}
+
+func (s *Server) account(id int) (*Account, error) {
+	account, ok := s.accounts[id]
+	if !ok {
+		return nil, newError("xUnknownAccount", "Account %v does not exist", id)
+	}
+	return account, nil
+}
+
+func (s *Server) accountByName(username string) *Account {
+	for _, account := range s.accounts {
+		if account.Username == username {
+			return account
+		}
+	}
+	return nil
+}
+
+// accountView fills in the fields derived from other objects
+func (s *Server) accountView(account *Account) Account {
+	view := *account
+	view.Volumes = []int{}
+	for _, volume := range s.sortedVolumes() {
+		if volume.AccountID == account.AccountID && volume.Status == "active" {
+			view.Volumes = append(view.Volumes, volume.VolumeID)
+		}
+	}
+	return view
+}
+
+func addAccount(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Username        string                 `json:"username"`
+		InitiatorSecret string                 `json:"initiatorSecret"`
+		TargetSecret    string                 `json:"targetSecret"`
+		EnableChap      *bool                  `json:"enableChap"`
+		Attributes      map[string]interface{} `json:"attributes"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	if p.Username == "" {
+		return nil, newError("xMissingParameter", "username is required")
+	}
+	if s.accountByName(p.Username) != nil {
+		return nil, newError("xDuplicateUsername", "Username %v is already in use", p.Username)
+	}
+
+	account := &Account{
+		AccountID:       s.newID("account"),
+		Username:        p.Username,
+		Status:          "active",
+		InitiatorSecret: p.InitiatorSecret,
+		TargetSecret:    p.TargetSecret,
+		EnableChap:      true,
+		Attributes:      p.Attributes,
+
+		StorageContainerID: noStorageContainerID,
+	}
+	if account.InitiatorSecret == "" {
+		account.InitiatorSecret = generateSecret()
+	}
+	if account.TargetSecret == "" {
+		account.TargetSecret = generateSecret()
+	}
+	if err := validateSecret("initiatorSecret", account.InitiatorSecret); err != nil {
+		return nil, err
+	}
+	if err := validateSecret("targetSecret", account.TargetSecret); err != nil {
+		return nil, err
+	}
+	if p.EnableChap != nil {
+		account.EnableChap = *p.EnableChap
+	}
+	if account.Attributes == nil {
+		account.Attributes = map[string]interface{}{}
+	}
+
+	s.accounts[account.AccountID] = account
+
+	return map[string]interface{}{
+		"accountID": account.AccountID,
+		"account":   s.accountView(account),
+	}, nil
+}
+
+func getAccountByID(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		AccountID int `json:"accountID"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	account, err := s.account(p.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"account": s.accountView(account)}, nil
+}
+
+func getAccountByName(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Username string `json:"username"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	account := s.accountByName(p.Username)
+	if account == nil {
+		return nil, newError("xUnknownAccount", "Account %v does not exist", p.Username)
+	}
+
+	return map[string]interface{}{"account": s.accountView(account)}, nil
+}
+
+func listAccounts(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		StartAccountID int `json:"startAccountID"`
+		Limit          int `json:"limit"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	for id := range s.accounts {
+		if id >= p.StartAccountID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+
+	accounts := []Account{}
+	for _, id := range ids {
+		if p.Limit > 0 && len(accounts) == p.Limit {
+			break
+		}
+		accounts = append(accounts, s.accountView(s.accounts[id]))
+	}
+
+	return map[string]interface{}{"accounts": accounts}, nil
+}
+
+func modifyAccount(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		AccountID       int                     `json:"accountID"`
+		Username        *string                 `json:"username"`
+		Status          *string                 `json:"status"`
+		InitiatorSecret *string                 `json:"initiatorSecret"`
+		TargetSecret    *string                 `json:"targetSecret"`
+		EnableChap      *bool                   `json:"enableChap"`
+		Attributes      *map[string]interface{} `json:"attributes"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	account, err := s.account(p.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Username != nil && *p.Username != "" && *p.Username != account.Username {
+		if s.accountByName(*p.Username) != nil {
+			return nil, newError("xDuplicateUsername", "Username %v is already in use", *p.Username)
+		}
+	}
+	if p.Status != nil && *p.Status != "" && *p.Status != "active" && *p.Status != "locked" {
+		return nil, newError("xInvalidParameter", "Invalid account status %v", *p.Status)
+	}
+	if p.InitiatorSecret != nil && *p.InitiatorSecret != "" {
+		if err := validateSecret("initiatorSecret", *p.InitiatorSecret); err != nil {
+			return nil, err
+		}
+	}
+	if p.TargetSecret != nil && *p.TargetSecret != "" {
+		if err := validateSecret("targetSecret", *p.TargetSecret); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.Username != nil && *p.Username != "" {
+		account.Username = *p.Username
+	}
+	if p.Status != nil && *p.Status != "" {
+		account.Status = *p.Status
+	}
+	if p.InitiatorSecret != nil && *p.InitiatorSecret != "" {
+		account.InitiatorSecret = *p.InitiatorSecret
+	}
+	if p.TargetSecret != nil && *p.TargetSecret != "" {
+		account.TargetSecret = *p.TargetSecret
+	}
+	if p.EnableChap != nil {
+		account.EnableChap = *p.EnableChap
+	}
+	if p.Attributes != nil && *p.Attributes != nil {
+		account.Attributes = *p.Attributes
+	}
+
+	return map[string]interface{}{"account": s.accountView(account)}, nil
+}
+
+func removeAccount(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		AccountID int `json:"accountID"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.account(p.AccountID); err != nil {
+		return nil, err
+	}
+
+	for _, volume := range s.volumes {
+		if volume.AccountID == p.AccountID {
+			return nil, newError("xAccountHasVolumes", "Account %v still owns volumes, which must be deleted and purged first", p.AccountID)
+		}
+	}
+
+	delete(s.accounts, p.AccountID)
+	return map[string]interface{}{}, nil
+}
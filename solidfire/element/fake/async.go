@@ -0,0 +1,133 @@
+package fake
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+func init() {
+	methods["GetAsyncResult"] = getAsyncResult
+	methods["ListAsyncResults"] = listAsyncResults
+}
+
+// asyncResult is a long-running operation. It is reported as running for the first pending polls and as
+// complete afterwards.
+type asyncResult struct {
+	handle     int
+	resultType string
+	createTime string
+	pending    int
+	result     interface{}
+	err        *Error
+}
+
+// newAsyncResult registers an operation of type resultType that completes with result and returns its handle
+func (s *Server) newAsyncResult(resultType string, result interface{}) int {
+	handle := s.newID("asyncHandle")
+	a := &asyncResult{
+		handle:     handle,
+		resultType: resultType,
+		createTime: s.timestamp(),
+		pending:    s.AsyncPolls,
+		result:     result,
+	}
+	if s.asyncFailure != "" {
+		a.err = newError(s.asyncFailure, "Injected async failure")
+	}
+	s.asyncResults[handle] = a
+	return handle
+}
+
+// FailAsyncResults makes the operations started from now on fail with the given Element error name once
+// they complete. An empty name lets them succeed again.
+func (s *Server) FailAsyncResults(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.asyncFailure = name
+}
+
+func (s *Server) asyncView(a *asyncResult) map[string]interface{} {
+	view := map[string]interface{}{
+		"resultType":     a.resultType,
+		"createTime":     a.createTime,
+		"lastUpdateTime": s.timestamp(),
+	}
+	if a.pending > 0 {
+		view["status"] = "running"
+		view["details"] = map[string]interface{}{"message": "Operation in progress"}
+		return view
+	}
+
+	view["status"] = "complete"
+	if a.err != nil {
+		view["error"] = a.err
+	} else {
+		view["result"] = a.result
+	}
+	return view
+}
+
+func getAsyncResult(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		AsyncHandle int  `json:"asyncHandle"`
+		KeepResult  bool `json:"keepResult"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	a, ok := s.asyncResults[p.AsyncHandle]
+	if !ok {
+		return nil, newError("xUnknownAsyncHandle", "Async handle %v does not exist", p.AsyncHandle)
+	}
+
+	view := s.asyncView(a)
+	if a.pending > 0 {
+		a.pending--
+	} else if !p.KeepResult {
+		delete(s.asyncResults, a.handle)
+	}
+	return view, nil
+}
+
+func listAsyncResults(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		AsyncResultTypes []string `json:"asyncResultTypes"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	var handles []int
+	for handle, a := range s.asyncResults {
+		if len(p.AsyncResultTypes) == 0 || containsString(p.AsyncResultTypes, a.resultType) {
+			handles = append(handles, handle)
+		}
+	}
+	sort.Ints(handles)
+
+	views := []map[string]interface{}{}
+	for _, handle := range handles {
+		a := s.asyncResults[handle]
+		views = append(views, map[string]interface{}{
+			"asyncResultID":  a.handle,
+			"completed":      a.pending == 0,
+			"success":        a.pending == 0 && a.err == nil,
+			"resultType":     a.resultType,
+			"createTime":     a.createTime,
+			"lastUpdateTime": s.timestamp(),
+			"data":           s.asyncView(a)["result"],
+		})
+	}
+
+	return map[string]interface{}{"asyncHandles": views}, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package fake
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+func init() {
+	methods["GetAPI"] = getAPI
+	methods["GetClusterVersionInfo"] = getClusterVersionInfo
+	methods["GetLimits"] = getLimits
+}
+
+func getAPI(s *Server, params json.RawMessage) (interface{}, error) {
+	var versions []json.Number
+	for _, v := range s.APIVersions {
+		versions = append(versions, json.Number(v))
+	}
+
+	return map[string]interface{}{
+		"currentVersion":    versions[len(versions)-1],
+		"supportedVersions": versions,
+	}, nil
+}
+
+func getClusterVersionInfo(s *Server, params json.RawMessage) (interface{}, error) {
+	latest := s.APIVersions[len(s.APIVersions)-1]
+	version, _ := strconv.ParseFloat(latest, 64)
+
+	return map[string]interface{}{
+		"clusterAPIVersion":  latest,
+		"clusterVersion":     strconv.FormatFloat(version, 'f', 1, 64) + ".0.0",
+		"clusterVersionInfo": []interface{}{},
+		"softwareVersionInfo": map[string]interface{}{
+			"currentVersion": latest,
+		},
+	}, nil
+}
+
+func getLimits(s *Server, params json.RawMessage) (interface{}, error) {
+	return map[string]interface{}{
+		"volumeMinIOPSMin":   volumeMinIOPSMin,
+		"volumeMinIOPSMax":   volumeMinIOPSMax,
+		"volumeMaxIOPSMin":   volumeMaxIOPSMin,
+		"volumeMaxIOPSMax":   volumeMaxIOPSMax,
+		"volumeBurstIOPSMin": volumeBurstIOPSMin,
+		"volumeBurstIOPSMax": volumeBurstIOPSMax,
+		"volumeSizeMin":      minVolumeSize,
+		"volumeSizeMax":      maxVolumeSize,
+		"secretLengthMin":    secretLengthMin,
+		"secretLengthMax":    secretLengthMax,
+	}, nil
+}
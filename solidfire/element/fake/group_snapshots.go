@@ -0,0 +1,326 @@
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+func init() {
+	methods["CreateGroupSnapshot"] = createGroupSnapshot
+	methods["ListGroupSnapshots"] = listGroupSnapshots
+	methods["ModifyGroupSnapshot"] = modifyGroupSnapshot
+	methods["DeleteGroupSnapshot"] = deleteGroupSnapshot
+	methods["RollbackToGroupSnapshot"] = rollbackToGroupSnapshot
+}
+
+// GroupSnapshot is an Element group snapshot as returned by the API. Members are filled in from the
+// snapshots of the group when the group snapshot is returned.
+type GroupSnapshot struct {
+	GroupSnapshotID         int                    `json:"groupSnapshotID"`
+	GroupSnapshotUUID       string                 `json:"groupSnapshotUUID"`
+	Name                    string                 `json:"name"`
+	Members                 []*Snapshot            `json:"members"`
+	CreateTime              string                 `json:"createTime"`
+	Status                  string                 `json:"status"`
+	EnableRemoteReplication bool                   `json:"enableRemoteReplication"`
+	Attributes              map[string]interface{} `json:"attributes"`
+}
+
+func (s *Server) groupSnapshot(id int) (*GroupSnapshot, error) {
+	s.purgeExpiredSnapshots()
+
+	group, ok := s.groupSnapshots[id]
+	if !ok {
+		return nil, newError("xGroupSnapshotIDDoesNotExist", "Group snapshot %v does not exist", id)
+	}
+	group.Members = s.groupMembers(id)
+	return group, nil
+}
+
+// groupMembers returns the snapshots of a group snapshot, ordered by volume ID
+func (s *Server) groupMembers(groupID int) []*Snapshot {
+	members := []*Snapshot{}
+	for _, snapshot := range s.snapshots {
+		if snapshot.GroupID == groupID {
+			members = append(members, snapshot)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].VolumeID < members[j].VolumeID })
+	return members
+}
+
+// newGroupSnapshot takes snapshots of volumes that expire after retention, or never if retention is zero, and
+// returns the group snapshot along with its members in the form CreateGroupSnapshot returns them
+func (s *Server) newGroupSnapshot(volumes []*Volume, name string, retention time.Duration, attributes map[string]interface{}) (*GroupSnapshot, []map[string]interface{}) {
+	id := s.newID("groupSnapshot")
+	uuid := fmt.Sprintf("00000000-0000-4000-9000-%012x", id)
+	if name == "" {
+		name = s.timestamp()
+	}
+
+	group := &GroupSnapshot{
+		GroupSnapshotID:   id,
+		GroupSnapshotUUID: uuid,
+		Name:              name,
+		CreateTime:        s.timestamp(),
+		Status:            "done",
+		Attributes:        map[string]interface{}{},
+	}
+	if attributes != nil {
+		group.Attributes = attributes
+	}
+
+	members := []map[string]interface{}{}
+	for _, volume := range volumes {
+		snapshot := s.newSnapshot(volume, name, retention, id, uuid)
+		members = append(members, map[string]interface{}{
+			"volumeID":     snapshot.VolumeID,
+			"snapshotID":   snapshot.SnapshotID,
+			"snapshotUUID": snapshot.SnapshotUUID,
+			"checksum":     snapshot.Checksum,
+		})
+	}
+
+	s.groupSnapshots[id] = group
+	group.Members = s.groupMembers(id)
+	return group, members
+}
+
+// purgeEmptyGroupSnapshots removes group snapshots whose members have all expired or been deleted
+func (s *Server) purgeEmptyGroupSnapshots() {
+	for id := range s.groupSnapshots {
+		if len(s.groupMembers(id)) == 0 {
+			delete(s.groupSnapshots, id)
+		}
+	}
+}
+
+func createGroupSnapshot(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Volumes                 []int                  `json:"volumes"`
+		Name                    string                 `json:"name"`
+		EnableRemoteReplication bool                   `json:"enableRemoteReplication"`
+		Retention               string                 `json:"retention"`
+		SnapMirrorLabel         string                 `json:"snapMirrorLabel"`
+		Attributes              map[string]interface{} `json:"attributes"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	if len(p.Volumes) == 0 {
+		return nil, newError("xInvalidParameter", "At least one volume is required")
+	}
+
+	var volumes []*Volume
+	seen := map[int]bool{}
+	for _, id := range p.Volumes {
+		if seen[id] {
+			return nil, newError("xInvalidParameter", "Volume %v is listed more than once", id)
+		}
+		seen[id] = true
+
+		volume, err := s.volume(id)
+		if err != nil {
+			return nil, err
+		}
+		if volume.Status != "active" {
+			return nil, newError("xVolumeIDDoesNotExist", "Volume %v is deleted", id)
+		}
+		volumes = append(volumes, volume)
+	}
+
+	var retention time.Duration
+	if p.Retention != "" {
+		var err error
+		if retention, err = parseRetention(p.Retention); err != nil {
+			return nil, err
+		}
+	}
+
+	group, members := s.newGroupSnapshot(volumes, p.Name, retention, p.Attributes)
+	for _, snapshot := range group.Members {
+		snapshot.EnableRemoteReplication = p.EnableRemoteReplication
+		snapshot.SnapMirrorLabel = p.SnapMirrorLabel
+	}
+	group.EnableRemoteReplication = p.EnableRemoteReplication
+
+	return map[string]interface{}{
+		"groupSnapshotID":   group.GroupSnapshotID,
+		"groupSnapshotUUID": group.GroupSnapshotUUID,
+		"members":           members,
+		"groupSnapshot":     group,
+	}, nil
+}
+
+func listGroupSnapshots(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Volumes         []int `json:"volumes"`
+		GroupSnapshotID int   `json:"groupSnapshotID"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	for _, id := range p.Volumes {
+		if _, err := s.volume(id); err != nil {
+			return nil, err
+		}
+	}
+
+	s.purgeExpiredSnapshots()
+
+	var ids []int
+	for id := range s.groupSnapshots {
+		if p.GroupSnapshotID != 0 && id != p.GroupSnapshotID {
+			continue
+		}
+		if len(p.Volumes) > 0 && !s.groupIncludesVolume(id, p.Volumes) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	groups := []*GroupSnapshot{}
+	for _, id := range ids {
+		group := s.groupSnapshots[id]
+		group.Members = s.groupMembers(id)
+		groups = append(groups, group)
+	}
+
+	return map[string]interface{}{"groupSnapshots": groups}, nil
+}
+
+func (s *Server) groupIncludesVolume(groupID int, volumes []int) bool {
+	for _, snapshot := range s.groupMembers(groupID) {
+		for _, id := range volumes {
+			if snapshot.VolumeID == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func modifyGroupSnapshot(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		GroupSnapshotID         int     `json:"groupSnapshotID"`
+		Name                    string  `json:"name"`
+		ExpirationTime          *string `json:"expirationTime"`
+		EnableRemoteReplication *bool   `json:"enableRemoteReplication"`
+		SnapMirrorLabel         *string `json:"snapMirrorLabel"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	group, err := s.groupSnapshot(p.GroupSnapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.ExpirationTime != nil {
+		if _, err := time.Parse(time.RFC3339, *p.ExpirationTime); err != nil {
+			return nil, newError("xInvalidParameter", "Invalid expirationTime %v", *p.ExpirationTime)
+		}
+	}
+
+	if p.Name != "" {
+		group.Name = p.Name
+	}
+	if p.EnableRemoteReplication != nil {
+		group.EnableRemoteReplication = *p.EnableRemoteReplication
+	}
+
+	// The settings of the group apply to all of its members
+	for _, snapshot := range group.Members {
+		if p.Name != "" {
+			snapshot.Name = p.Name
+		}
+		if p.ExpirationTime != nil {
+			expiration := *p.ExpirationTime
+			snapshot.ExpirationTime = &expiration
+			snapshot.ExpirationReason = "Retention"
+		}
+		if p.EnableRemoteReplication != nil {
+			snapshot.EnableRemoteReplication = *p.EnableRemoteReplication
+		}
+		if p.SnapMirrorLabel != nil {
+			snapshot.SnapMirrorLabel = *p.SnapMirrorLabel
+		}
+	}
+
+	return map[string]interface{}{"groupSnapshot": group}, nil
+}
+
+func deleteGroupSnapshot(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		GroupSnapshotID int  `json:"groupSnapshotID"`
+		SaveMembers     bool `json:"saveMembers"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	group, err := s.groupSnapshot(p.GroupSnapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Saved members become individual snapshots of their volumes
+	for _, snapshot := range group.Members {
+		if p.SaveMembers {
+			snapshot.GroupID = 0
+			snapshot.GroupSnapshotUUID = ""
+		} else {
+			delete(s.snapshots, snapshot.SnapshotID)
+		}
+	}
+
+	delete(s.groupSnapshots, p.GroupSnapshotID)
+	return map[string]interface{}{}, nil
+}
+
+func rollbackToGroupSnapshot(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		GroupSnapshotID  int                    `json:"groupSnapshotID"`
+		SaveCurrentState bool                   `json:"saveCurrentState"`
+		Name             string                 `json:"name"`
+		Attributes       map[string]interface{} `json:"attributes"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	group, err := s.groupSnapshot(p.GroupSnapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	var volumes []*Volume
+	for _, member := range group.Members {
+		volume, err := s.volume(member.VolumeID)
+		if err != nil {
+			return nil, err
+		}
+		if volume.Status != "active" {
+			return nil, newError("xVolumeIDDoesNotExist", "Volume %v is deleted", member.VolumeID)
+		}
+		volumes = append(volumes, volume)
+	}
+
+	if !p.SaveCurrentState {
+		return map[string]interface{}{"groupSnapshotID": 0, "groupSnapshotUUID": "", "members": []interface{}{}}, nil
+	}
+
+	saved, members := s.newGroupSnapshot(volumes, p.Name, 0, p.Attributes)
+
+	return map[string]interface{}{
+		"groupSnapshotID":   saved.GroupSnapshotID,
+		"groupSnapshotUUID": saved.GroupSnapshotUUID,
+		"members":           members,
+	}, nil
+}
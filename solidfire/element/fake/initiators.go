@@ -0,0 +1,239 @@
+package fake
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+func init() {
+	methods["CreateInitiators"] = createInitiators
+	methods["ListInitiators"] = listInitiators
+	methods["ModifyInitiators"] = modifyInitiators
+	methods["DeleteInitiators"] = deleteInitiators
+}
+
+// Initiator is an Element initiator as returned by the API
+type Initiator struct {
+	InitiatorID        int                    `json:"initiatorID"`
+	InitiatorName      string                 `json:"initiatorName"`
+	Alias              string                 `json:"alias"`
+	Attributes         map[string]interface{} `json:"attributes"`
+	VolumeAccessGroups []int                  `json:"volumeAccessGroups"`
+}
+
+func (s *Server) initiator(id int) (*Initiator, error) {
+	initiator, ok := s.initiators[id]
+	if !ok {
+		return nil, newError("xInitiatorDoesNotExist", "Initiator %v does not exist", id)
+	}
+	return initiator, nil
+}
+
+func (s *Server) initiatorByName(name string) *Initiator {
+	for _, initiator := range s.initiators {
+		if initiator.InitiatorName == name {
+			return initiator
+		}
+	}
+	return nil
+}
+
+func (s *Server) newInitiator(name string, alias string, attributes map[string]interface{}) *Initiator {
+	if attributes == nil {
+		attributes = map[string]interface{}{}
+	}
+	initiator := &Initiator{
+		InitiatorID:   s.newID("initiator"),
+		InitiatorName: name,
+		Alias:         alias,
+		Attributes:    attributes,
+	}
+	s.initiators[initiator.InitiatorID] = initiator
+	return initiator
+}
+
+// initiatorView fills in the fields derived from other objects
+func (s *Server) initiatorView(initiator *Initiator) Initiator {
+	view := *initiator
+	view.VolumeAccessGroups = []int{}
+	for _, group := range s.sortedAccessGroups() {
+		if containsInt(group.InitiatorIDs, initiator.InitiatorID) {
+			view.VolumeAccessGroups = append(view.VolumeAccessGroups, group.VolumeAccessGroupID)
+		}
+	}
+	return view
+}
+
+// setInitiatorAccessGroup moves an initiator into a single volume access group, or out of all of them
+func (s *Server) setInitiatorAccessGroup(initiator *Initiator, groupID int) {
+	for _, group := range s.accessGroups {
+		group.InitiatorIDs = removeInt(group.InitiatorIDs, initiator.InitiatorID)
+	}
+	if group, ok := s.accessGroups[groupID]; ok {
+		group.InitiatorIDs = append(group.InitiatorIDs, initiator.InitiatorID)
+	}
+}
+
+func (s *Server) deleteOrphanInitiators(ids []int) {
+	for _, id := range ids {
+		orphan := true
+		for _, group := range s.accessGroups {
+			if containsInt(group.InitiatorIDs, id) {
+				orphan = false
+			}
+		}
+		if orphan {
+			delete(s.initiators, id)
+		}
+	}
+}
+
+type initiatorParams struct {
+	InitiatorID         int                     `json:"initiatorID"`
+	Name                string                  `json:"name"`
+	Alias               *string                 `json:"alias"`
+	Attributes          *map[string]interface{} `json:"attributes"`
+	VolumeAccessGroupID *int                    `json:"volumeAccessGroupID"`
+}
+
+func createInitiators(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Initiators []initiatorParams `json:"initiators"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	for _, i := range p.Initiators {
+		if i.Name == "" {
+			return nil, newError("xMissingParameter", "Initiator name is required")
+		}
+		if s.initiatorByName(i.Name) != nil {
+			return nil, newError("xInitiatorExists", "Initiator %v already exists", i.Name)
+		}
+		if i.VolumeAccessGroupID != nil && *i.VolumeAccessGroupID != 0 {
+			if _, err := s.accessGroup(*i.VolumeAccessGroupID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	created := []Initiator{}
+	for _, i := range p.Initiators {
+		alias := ""
+		if i.Alias != nil {
+			alias = *i.Alias
+		}
+		var attributes map[string]interface{}
+		if i.Attributes != nil {
+			attributes = *i.Attributes
+		}
+
+		initiator := s.newInitiator(i.Name, alias, attributes)
+		if i.VolumeAccessGroupID != nil {
+			s.setInitiatorAccessGroup(initiator, *i.VolumeAccessGroupID)
+		}
+		created = append(created, s.initiatorView(initiator))
+	}
+
+	return map[string]interface{}{"initiators": created}, nil
+}
+
+func listInitiators(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		StartInitiatorID int   `json:"startInitiatorID"`
+		Limit            int   `json:"limit"`
+		Initiators       []int `json:"initiators"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	if len(p.Initiators) > 0 {
+		for _, id := range p.Initiators {
+			if _, err := s.initiator(id); err != nil {
+				return nil, err
+			}
+			ids = append(ids, id)
+		}
+	} else {
+		for id := range s.initiators {
+			if id >= p.StartInitiatorID {
+				ids = append(ids, id)
+			}
+		}
+		sort.Ints(ids)
+	}
+
+	initiators := []Initiator{}
+	for _, id := range ids {
+		if p.Limit > 0 && len(initiators) == p.Limit {
+			break
+		}
+		initiators = append(initiators, s.initiatorView(s.initiators[id]))
+	}
+
+	return map[string]interface{}{"initiators": initiators}, nil
+}
+
+func modifyInitiators(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Initiators []initiatorParams `json:"initiators"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	for _, i := range p.Initiators {
+		if _, err := s.initiator(i.InitiatorID); err != nil {
+			return nil, err
+		}
+		if i.VolumeAccessGroupID != nil && *i.VolumeAccessGroupID != 0 {
+			if _, err := s.accessGroup(*i.VolumeAccessGroupID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	modified := []Initiator{}
+	for _, i := range p.Initiators {
+		initiator := s.initiators[i.InitiatorID]
+		if i.Alias != nil {
+			initiator.Alias = *i.Alias
+		}
+		if i.Attributes != nil && *i.Attributes != nil {
+			initiator.Attributes = *i.Attributes
+		}
+		if i.VolumeAccessGroupID != nil {
+			s.setInitiatorAccessGroup(initiator, *i.VolumeAccessGroupID)
+		}
+		modified = append(modified, s.initiatorView(initiator))
+	}
+
+	return map[string]interface{}{"initiators": modified}, nil
+}
+
+func deleteInitiators(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Initiators []int `json:"initiators"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	for _, id := range p.Initiators {
+		if _, err := s.initiator(id); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, id := range p.Initiators {
+		for _, group := range s.accessGroups {
+			group.InitiatorIDs = removeInt(group.InitiatorIDs, id)
+		}
+		delete(s.initiators, id)
+	}
+
+	return map[string]interface{}{}, nil
+}
@@ -0,0 +1,162 @@
+package fake
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+func init() {
+	methods["CreateQoSPolicy"] = createQoSPolicy
+	methods["ListQoSPolicies"] = listQoSPolicies
+	methods["ModifyQoSPolicy"] = modifyQoSPolicy
+	methods["DeleteQoSPolicy"] = deleteQoSPolicy
+}
+
+// QoSPolicy is an Element QoS policy as returned by the API. VolumeIDs is filled in from the volumes
+// associated with the policy when the policy is returned.
+type QoSPolicy struct {
+	QoSPolicyID int    `json:"qosPolicyID"`
+	Name        string `json:"name"`
+	QoS         QoS    `json:"qos"`
+	VolumeIDs   []int  `json:"volumeIDs"`
+}
+
+func (s *Server) qosPolicy(id int) (*QoSPolicy, error) {
+	policy, ok := s.qosPolicies[id]
+	if !ok {
+		return nil, newError("xQoSPolicyDoesNotExist", "QoS policy %v does not exist", id)
+	}
+	policy.VolumeIDs = s.qosPolicyVolumes(id)
+	return policy, nil
+}
+
+// qosPolicyVolumes returns the IDs of the volumes associated with a QoS policy, including deleted volumes
+// that have not been purged
+func (s *Server) qosPolicyVolumes(policyID int) []int {
+	ids := []int{}
+	for id, volume := range s.volumes {
+		if volume.QoSPolicyID != nil && *volume.QoSPolicyID == policyID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func (s *Server) validateQoSPolicyName(name string, id int) error {
+	if name == "" {
+		return newError("xMissingParameter", "name is required")
+	}
+	for _, policy := range s.qosPolicies {
+		if policy.Name == name && policy.QoSPolicyID != id {
+			return newError("xQoSPolicyNameExists", "QoS policy %v already exists", name)
+		}
+	}
+	return nil
+}
+
+func createQoSPolicy(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Name string `json:"name"`
+		QoS  *QoS   `json:"qos"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateQoSPolicyName(p.Name, 0); err != nil {
+		return nil, err
+	}
+	if p.QoS == nil {
+		return nil, newError("xMissingParameter", "qos is required")
+	}
+
+	qos := mergeQoS(QoS{MinIOPS: defaultMinIOPS, MaxIOPS: defaultMaxIOPS, BurstIOPS: defaultBurstIOPS, BurstTime: 60}, p.QoS)
+	if err := validateQoS(qos); err != nil {
+		return nil, err
+	}
+
+	policy := &QoSPolicy{
+		QoSPolicyID: s.newID("qosPolicy"),
+		Name:        p.Name,
+		QoS:         qos,
+		VolumeIDs:   []int{},
+	}
+	s.qosPolicies[policy.QoSPolicyID] = policy
+
+	return map[string]interface{}{"qosPolicy": policy}, nil
+}
+
+func listQoSPolicies(s *Server, params json.RawMessage) (interface{}, error) {
+	var ids []int
+	for id := range s.qosPolicies {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	policies := []*QoSPolicy{}
+	for _, id := range ids {
+		policy, _ := s.qosPolicy(id)
+		policies = append(policies, policy)
+	}
+
+	return map[string]interface{}{"qosPolicies": policies}, nil
+}
+
+func modifyQoSPolicy(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		QoSPolicyID int    `json:"qosPolicyID"`
+		Name        string `json:"name"`
+		QoS         *QoS   `json:"qos"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	policy, err := s.qosPolicy(p.QoSPolicyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Name != "" {
+		if err := s.validateQoSPolicyName(p.Name, policy.QoSPolicyID); err != nil {
+			return nil, err
+		}
+	}
+	qos := mergeQoS(policy.QoS, p.QoS)
+	if err := validateQoS(qos); err != nil {
+		return nil, err
+	}
+
+	if p.Name != "" {
+		policy.Name = p.Name
+	}
+	policy.QoS = qos
+
+	// The QoS of the associated volumes follows the policy
+	for _, id := range policy.VolumeIDs {
+		s.volumes[id].QoS = qos
+	}
+
+	return map[string]interface{}{"qosPolicy": policy}, nil
+}
+
+func deleteQoSPolicy(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		QoSPolicyID int `json:"qosPolicyID"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	policy, err := s.qosPolicy(p.QoSPolicyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(policy.VolumeIDs) > 0 {
+		return nil, newError("xQoSPolicyInUse", "QoS policy %v is associated with volumes %v", p.QoSPolicyID, policy.VolumeIDs)
+	}
+
+	delete(s.qosPolicies, p.QoSPolicyID)
+	return map[string]interface{}{}, nil
+}
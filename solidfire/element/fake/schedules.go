@@ -0,0 +1,299 @@
+package fake
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// Schedule frequencies, stored in the frequency attribute of a schedule
+const (
+	frequencyTimeInterval = "Time Interval"
+	frequencyDaysOfWeek   = "Days Of Week"
+	frequencyDaysOfMonth  = "Days Of Month"
+)
+
+func init() {
+	methods["CreateSchedule"] = createSchedule
+	methods["ListSchedules"] = listSchedules
+	methods["GetSchedule"] = getSchedule
+	methods["ModifySchedule"] = modifySchedule
+}
+
+// ScheduleWeekday is a day of the week on which a schedule runs
+type ScheduleWeekday struct {
+	Day    int `json:"day"`
+	Offset int `json:"offset"`
+}
+
+// ScheduleInfo describes the snapshots taken by a schedule
+type ScheduleInfo struct {
+	VolumeID                int    `json:"volumeID,omitempty"`
+	Volumes                 []int  `json:"volumes,omitempty"`
+	Name                    string `json:"name,omitempty"`
+	EnableRemoteReplication bool   `json:"enableRemoteReplication"`
+	Retention               string `json:"retention,omitempty"`
+}
+
+// Schedule is an Element snapshot schedule as returned by the API. The fake cluster stores schedules but
+// does not run them.
+type Schedule struct {
+	ScheduleID         int                    `json:"scheduleID"`
+	ScheduleName       string                 `json:"scheduleName"`
+	ScheduleType       string                 `json:"scheduleType"`
+	ScheduleInfo       ScheduleInfo           `json:"scheduleInfo"`
+	Attributes         map[string]interface{} `json:"attributes"`
+	Hours              int                    `json:"hours"`
+	Minutes            int                    `json:"minutes"`
+	Weekdays           []ScheduleWeekday      `json:"weekdays"`
+	Monthdays          []int                  `json:"monthdays"`
+	Paused             bool                   `json:"paused"`
+	Recurring          bool                   `json:"recurring"`
+	RunNextInterval    bool                   `json:"runNextInterval"`
+	StartingDate       string                 `json:"startingDate"`
+	LastRunTimeStarted *string                `json:"lastRunTimeStarted"`
+	LastRunStatus      string                 `json:"lastRunStatus"`
+	HasError           bool                   `json:"hasError"`
+	ToBeDeleted        bool                   `json:"toBeDeleted"`
+}
+
+func (s *Server) schedule(id int) (*Schedule, error) {
+	schedule, ok := s.schedules[id]
+	if !ok {
+		return nil, newError("xScheduleIDDoesNotExist", "Schedule %v does not exist", id)
+	}
+	return schedule, nil
+}
+
+// validateSchedule checks a new or modified schedule the way the cluster does
+func (s *Server) validateSchedule(schedule *Schedule) error {
+	if schedule.ScheduleName == "" {
+		return newError("xInvalidParameter", "scheduleName is required")
+	}
+	if schedule.ScheduleType != "Snapshot" {
+		return newError("xInvalidParameter", "Invalid scheduleType %v", schedule.ScheduleType)
+	}
+
+	info := schedule.ScheduleInfo
+	if (info.VolumeID == 0) == (len(info.Volumes) == 0) {
+		return newError("xInvalidParameter", "scheduleInfo requires either volumeID or volumes")
+	}
+	volumes := info.Volumes
+	if info.VolumeID != 0 {
+		volumes = []int{info.VolumeID}
+	}
+	for _, id := range volumes {
+		if _, err := s.volume(id); err != nil {
+			return err
+		}
+	}
+	if info.Retention != "" {
+		if _, err := parseRetention(info.Retention); err != nil {
+			return err
+		}
+	}
+
+	if schedule.Minutes < 0 || schedule.Minutes > 59 || schedule.Hours < 0 {
+		return newError("xInvalidParameter", "Invalid time %v:%v", schedule.Hours, schedule.Minutes)
+	}
+
+	frequency, _ := schedule.Attributes["frequency"].(string)
+	switch frequency {
+	case frequencyTimeInterval:
+		if schedule.Hours == 0 && schedule.Minutes == 0 {
+			return newError("xInvalidParameter", "The interval of a schedule cannot be zero")
+		}
+	case frequencyDaysOfWeek:
+		if schedule.Hours > 23 {
+			return newError("xInvalidParameter", "Invalid hour %v", schedule.Hours)
+		}
+		if len(schedule.Weekdays) == 0 {
+			return newError("xInvalidParameter", "weekdays is required for frequency %v", frequency)
+		}
+		for _, weekday := range schedule.Weekdays {
+			if weekday.Day < 0 || weekday.Day > 6 {
+				return newError("xInvalidParameter", "Invalid weekday %v", weekday.Day)
+			}
+		}
+	case frequencyDaysOfMonth:
+		if schedule.Hours > 23 {
+			return newError("xInvalidParameter", "Invalid hour %v", schedule.Hours)
+		}
+		if len(schedule.Monthdays) == 0 {
+			return newError("xInvalidParameter", "monthdays is required for frequency %v", frequency)
+		}
+		for _, day := range schedule.Monthdays {
+			if day < 1 || day > 31 {
+				return newError("xInvalidParameter", "Invalid day of month %v", day)
+			}
+		}
+	default:
+		return newError("xInvalidParameter", "Invalid frequency %q", frequency)
+	}
+
+	return nil
+}
+
+func createSchedule(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		ScheduleName    string                 `json:"scheduleName"`
+		ScheduleType    string                 `json:"scheduleType"`
+		ScheduleInfo    ScheduleInfo           `json:"scheduleInfo"`
+		Attributes      map[string]interface{} `json:"attributes"`
+		Hours           int                    `json:"hours"`
+		Minutes         int                    `json:"minutes"`
+		Weekdays        []ScheduleWeekday      `json:"weekdays"`
+		Monthdays       []int                  `json:"monthdays"`
+		Paused          bool                   `json:"paused"`
+		Recurring       bool                   `json:"recurring"`
+		RunNextInterval bool                   `json:"runNextInterval"`
+		StartingDate    string                 `json:"startingDate"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	schedule := &Schedule{
+		ScheduleName:    p.ScheduleName,
+		ScheduleType:    p.ScheduleType,
+		ScheduleInfo:    p.ScheduleInfo,
+		Attributes:      p.Attributes,
+		Hours:           p.Hours,
+		Minutes:         p.Minutes,
+		Weekdays:        p.Weekdays,
+		Monthdays:       p.Monthdays,
+		Paused:          p.Paused,
+		Recurring:       p.Recurring,
+		RunNextInterval: p.RunNextInterval,
+		StartingDate:    p.StartingDate,
+	}
+	if schedule.Attributes == nil {
+		schedule.Attributes = map[string]interface{}{}
+	}
+	if schedule.Weekdays == nil {
+		schedule.Weekdays = []ScheduleWeekday{}
+	}
+	if schedule.Monthdays == nil {
+		schedule.Monthdays = []int{}
+	}
+	if schedule.StartingDate == "" {
+		schedule.StartingDate = s.timestamp()
+	}
+
+	if err := s.validateSchedule(schedule); err != nil {
+		return nil, err
+	}
+
+	schedule.ScheduleID = s.newID("schedule")
+	s.schedules[schedule.ScheduleID] = schedule
+
+	return map[string]interface{}{
+		"scheduleID": schedule.ScheduleID,
+		"schedule":   schedule,
+	}, nil
+}
+
+func listSchedules(s *Server, params json.RawMessage) (interface{}, error) {
+	var ids []int
+	for id := range s.schedules {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	schedules := []*Schedule{}
+	for _, id := range ids {
+		schedules = append(schedules, s.schedules[id])
+	}
+
+	return map[string]interface{}{"schedules": schedules}, nil
+}
+
+func getSchedule(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		ScheduleID int `json:"scheduleID"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	schedule, err := s.schedule(p.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"schedule": schedule}, nil
+}
+
+func modifySchedule(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		ScheduleID      int                    `json:"scheduleID"`
+		ScheduleName    string                 `json:"scheduleName"`
+		ScheduleInfo    *ScheduleInfo          `json:"scheduleInfo"`
+		Attributes      map[string]interface{} `json:"attributes"`
+		Hours           *int                   `json:"hours"`
+		Minutes         *int                   `json:"minutes"`
+		Weekdays        []ScheduleWeekday      `json:"weekdays"`
+		Monthdays       []int                  `json:"monthdays"`
+		Paused          *bool                  `json:"paused"`
+		Recurring       *bool                  `json:"recurring"`
+		RunNextInterval *bool                  `json:"runNextInterval"`
+		StartingDate    string                 `json:"startingDate"`
+		ToBeDeleted     *bool                  `json:"toBeDeleted"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	current, err := s.schedule(p.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deleting a schedule ignores the other parameters. The cluster removes it once it is not running.
+	if p.ToBeDeleted != nil && *p.ToBeDeleted {
+		current.ToBeDeleted = true
+		delete(s.schedules, p.ScheduleID)
+		return map[string]interface{}{"schedule": current}, nil
+	}
+
+	schedule := *current
+	if p.ScheduleName != "" {
+		schedule.ScheduleName = p.ScheduleName
+	}
+	if p.ScheduleInfo != nil {
+		schedule.ScheduleInfo = *p.ScheduleInfo
+	}
+	if p.Attributes != nil {
+		schedule.Attributes = p.Attributes
+	}
+	if p.Hours != nil {
+		schedule.Hours = *p.Hours
+	}
+	if p.Minutes != nil {
+		schedule.Minutes = *p.Minutes
+	}
+	if p.Weekdays != nil {
+		schedule.Weekdays = p.Weekdays
+	}
+	if p.Monthdays != nil {
+		schedule.Monthdays = p.Monthdays
+	}
+	if p.Paused != nil {
+		schedule.Paused = *p.Paused
+	}
+	if p.Recurring != nil {
+		schedule.Recurring = *p.Recurring
+	}
+	if p.RunNextInterval != nil {
+		schedule.RunNextInterval = *p.RunNextInterval
+	}
+	if p.StartingDate != "" {
+		schedule.StartingDate = p.StartingDate
+	}
+
+	if err := s.validateSchedule(&schedule); err != nil {
+		return nil, err
+	}
+
+	*current = schedule
+	return map[string]interface{}{"schedule": current}, nil
+}
@@ -0,0 +1,227 @@
+// Package fake provides an in-memory Element cluster that speaks the Element JSON-RPC API. It is meant for
+// running the provider and its acceptance tests without access to a real SolidFire cluster.
+package fake
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultAPIVersions are the Element API versions a Server reports as supported unless configured otherwise
+var DefaultAPIVersions = []string{"1.0", "8.0", "9.0", "10.0", "11.0", "12.0"}
+
+type methodFunc func(s *Server, params json.RawMessage) (interface{}, error)
+
+var methods = map[string]methodFunc{}
+
+// Error is an Element API error, returned to the client in the JSON-RPC error object
+type Error struct {
+	Code    int    `json:"code"`
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%v: %v", e.Name, e.Message)
+}
+
+func newError(name string, format string, args ...interface{}) *Error {
+	return &Error{Code: 500, Name: name, Message: fmt.Sprintf(format, args...)}
+}
+
+// Server is a fake Element cluster. All state is held in memory and lost when the server is closed.
+type Server struct {
+	// Username and Password are the cluster admin credentials. Requests with other credentials are rejected.
+	Username string
+	Password string
+	// APIVersions are the API versions the cluster supports
+	APIVersions []string
+	// AsyncPolls is the number of times GetAsyncResult reports an operation as running before it completes
+	AsyncPolls int
+
+	mu             sync.Mutex
+	httpServer     *httptest.Server
+	now            func() time.Time
+	nextID         map[string]int
+	injectedErrors map[string][]*Error
+	asyncResults   map[int]*asyncResult
+	asyncFailure   string
+
+	accounts       map[int]*Account
+	volumes        map[int]*Volume
+	accessGroups   map[int]*VolumeAccessGroup
+	initiators     map[int]*Initiator
+	sessions       map[int]*ISCSISession
+	snapshots      map[int]*Snapshot
+	groupSnapshots map[int]*GroupSnapshot
+	schedules      map[int]*Schedule
+	qosPolicies    map[int]*QoSPolicy
+}
+
+// NewServer starts a fake cluster listening on a local TLS port
+func NewServer() *Server {
+	s := newServer()
+	s.httpServer = httptest.NewTLSServer(s)
+	return s
+}
+
+// NewUnstartedServer returns a fake cluster that is not listening, for use as an http.Handler
+func NewUnstartedServer() *Server {
+	return newServer()
+}
+
+func newServer() *Server {
+	return &Server{
+		Username:       "admin",
+		Password:       "admin",
+		APIVersions:    DefaultAPIVersions,
+		AsyncPolls:     1,
+		now:            time.Now,
+		nextID:         map[string]int{},
+		injectedErrors: map[string][]*Error{},
+		asyncResults:   map[int]*asyncResult{},
+		accounts:       map[int]*Account{},
+		volumes:        map[int]*Volume{},
+		accessGroups:   map[int]*VolumeAccessGroup{},
+		initiators:     map[int]*Initiator{},
+		sessions:       map[int]*ISCSISession{},
+		snapshots:      map[int]*Snapshot{},
+		groupSnapshots: map[int]*GroupSnapshot{},
+		schedules:      map[int]*Schedule{},
+		qosPolicies:    map[int]*QoSPolicy{},
+	}
+}
+
+// Close shuts down the server
+func (s *Server) Close() {
+	if s.httpServer != nil {
+		s.httpServer.Close()
+	}
+}
+
+// Host returns the host:port of the server, suitable for the provider's solidfire_server argument
+func (s *Server) Host() string {
+	return strings.TrimPrefix(s.httpServer.URL, "https://")
+}
+
+// URL returns the base URL of the server
+func (s *Server) URL() string {
+	return s.httpServer.URL
+}
+
+// CertFingerprint returns the SHA-256 fingerprint of the server certificate, suitable for the provider's
+// cert_fingerprint argument
+func (s *Server) CertFingerprint() string {
+	sum := sha256.Sum256(s.httpServer.Certificate().Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// InjectError makes the next call to method fail with the given Element error name. Errors injected for
+// the same method are returned in order.
+func (s *Server) InjectError(method string, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.injectedErrors[method] = append(s.injectedErrors[method], newError(name, "Injected error"))
+}
+
+type request struct {
+	ID     interface{}     `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+type response struct {
+	ID     interface{} `json:"id"`
+	Result interface{} `json:"result,omitempty"`
+	Error  *Error      `json:"error,omitempty"`
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || !strings.HasPrefix(r.URL.Path, "/json-rpc/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	if user, pass, _ := r.BasicAuth(); user != s.Username || pass != s.Password {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	res := response{ID: req.ID}
+	result, err := s.call(strings.TrimPrefix(r.URL.Path, "/json-rpc/"), req.Method, req.Params)
+	if err != nil {
+		if rpcErr, ok := err.(*Error); ok {
+			res.Error = rpcErr
+		} else {
+			res.Error = newError("xUnknown", "%v", err)
+		}
+	} else {
+		res.Result = result
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) call(version string, method string, params json.RawMessage) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.supportsVersion(version) {
+		return nil, newError("xUnknownAPIVersion", "API version %v is not supported", version)
+	}
+
+	if injected := s.injectedErrors[method]; len(injected) > 0 {
+		s.injectedErrors[method] = injected[1:]
+		return nil, injected[0]
+	}
+
+	f, ok := methods[method]
+	if !ok {
+		return nil, newError("xUnknownAPIMethod", "Unknown API method %v", method)
+	}
+
+	if len(params) == 0 || string(params) == "null" {
+		params = json.RawMessage("{}")
+	}
+	return f(s, params)
+}
+
+func (s *Server) supportsVersion(version string) bool {
+	for _, v := range s.APIVersions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) newID(kind string) int {
+	s.nextID[kind]++
+	return s.nextID[kind]
+}
+
+func (s *Server) timestamp() string {
+	return s.now().UTC().Format(time.RFC3339)
+}
+
+// decodeParams unmarshals the method parameters, reporting malformed parameters the way the cluster does
+func decodeParams(params json.RawMessage, v interface{}) error {
+	if err := json.Unmarshal(params, v); err != nil {
+		return newError("xInvalidParameterType", "Invalid parameters: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,847 @@
+package fake_test
+
+import (
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element"
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element/fake"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(server *fake.Server) *element.Client {
+	client := &element.Client{
+		Host:     server.URL(),
+		Username: server.Username,
+		Password: server.Password,
+		HTTPTransport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+	client.SetAPIVersion("8.0")
+	return client
+}
+
+func TestVolumeLifecycle(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	client := newTestClient(server)
+
+	account, err := client.AddAccount(element.AddAccountRequest{Username: "tenant"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "tenant", account.Account.Username)
+	assert.Len(t, account.Account.InitiatorSecret, 12)
+
+	volume, err := client.CreateVolume(element.CreateVolumeRequest{
+		Name:       "data",
+		AccountID:  account.AccountID,
+		TotalSize:  1073741824,
+		Enable512e: true,
+		QoS:        &element.QoS{MaxIOPS: 2000},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := client.GetVolumeByID(strconv.Itoa(volume.VolumeID))
+	assert.NoError(t, err)
+	assert.Equal(t, "data", v.Name)
+	assert.Equal(t, account.AccountID, v.AccountID)
+	assert.Equal(t, 2000, v.QoS.MaxIOPS)
+	assert.Equal(t, 50, v.QoS.MinIOPS)
+
+	_, err = client.RemoveAccount(element.RemoveAccountRequest{AccountID: account.AccountID})
+	assert.True(t, errors.Is(err, element.ErrInUse), "expected ErrInUse, got %v", err)
+
+	_, err = client.DeleteVolume(element.DeleteVolumeRequest{VolumeID: volume.VolumeID})
+	assert.NoError(t, err)
+	_, err = client.PurgeDeletedVolume(element.PurgeDeletedVolumeRequest{VolumeID: volume.VolumeID})
+	assert.NoError(t, err)
+
+	_, err = client.GetVolumeByID(strconv.Itoa(volume.VolumeID))
+	assert.True(t, errors.Is(err, element.ErrNotFound), "expected ErrNotFound, got %v", err)
+
+	_, err = client.RemoveAccount(element.RemoveAccountRequest{AccountID: account.AccountID})
+	assert.NoError(t, err)
+	_, err = client.GetAccountByID(element.GetAccountByIDRequest{AccountID: account.AccountID})
+	assert.True(t, errors.Is(err, element.ErrNotFound), "expected ErrNotFound, got %v", err)
+}
+
+func TestFifoSize(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	client := newTestClient(server)
+
+	account, err := client.AddAccount(element.AddAccountRequest{Username: "tenant"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	minFifoSize := 4
+	volume, err := client.CreateVolume(element.CreateVolumeRequest{
+		Name:        "data",
+		AccountID:   account.AccountID,
+		TotalSize:   1073741824,
+		FifoSize:    8,
+		MinFifoSize: &minFifoSize,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 8, volume.Volume.FifoSize)
+	assert.Equal(t, 4, volume.Volume.MinFifoSize)
+
+	minFifoSize = 0
+	modified, err := client.ModifyVolume(element.ModifyVolumeRequest{VolumeID: volume.VolumeID, FifoSize: 16, MinFifoSize: &minFifoSize})
+	assert.NoError(t, err)
+	assert.Equal(t, 16, modified.Volume.FifoSize)
+	assert.Equal(t, 0, modified.Volume.MinFifoSize)
+
+	minFifoSize = 20
+	_, err = client.ModifyVolume(element.ModifyVolumeRequest{VolumeID: volume.VolumeID, MinFifoSize: &minFifoSize})
+	assert.True(t, errors.Is(err, element.ErrInvalidParameter), "expected ErrInvalidParameter, got %v", err)
+}
+
+func TestInitiatorsAndAccessGroups(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	client := newTestClient(server)
+
+	vag, err := client.CreateVolumeAccessGroup(element.CreateVolumeAccessGroupRequest{Name: "hosts"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	initiators, err := client.CreateInitiators(element.CreateInitiatorsRequest{
+		Initiators: []element.CreateInitiator{{
+			Name:                "iqn.1998-01.com.vmware:host-1",
+			Alias:               "host-1",
+			VolumeAccessGroupID: vag.VolumeAccessGroupID,
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, initiators.Initiators, 1) {
+		assert.Equal(t, []int{vag.VolumeAccessGroupID}, initiators.Initiators[0].VolumeAccessGroups)
+	}
+
+	group, err := client.GetVolumeAccessGroupByID(strconv.Itoa(vag.VolumeAccessGroupID))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"iqn.1998-01.com.vmware:host-1"}, group.Initiators)
+
+	_, err = client.DeleteVolumeAccessGroup(element.DeleteVolumeAccessGroupRequest{VolumeAccessGroupID: vag.VolumeAccessGroupID})
+	assert.NoError(t, err)
+	_, err = client.GetVolumeAccessGroupByID(strconv.Itoa(vag.VolumeAccessGroupID))
+	assert.True(t, errors.Is(err, element.ErrNotFound), "expected ErrNotFound, got %v", err)
+}
+
+func TestInjectError(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	client := newTestClient(server)
+
+	server.InjectError("ListVolumes", "xNotPrimary")
+
+	_, err := client.CallAPIMethod("ListVolumes", nil)
+	var apiErr *element.APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, "xNotPrimary", apiErr.Name)
+		assert.True(t, errors.Is(err, element.ErrTransient))
+	}
+
+	_, err = client.CallAPIMethod("ListVolumes", nil)
+	assert.NoError(t, err)
+}
+
+func TestAuthentication(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	client := newTestClient(server)
+	client.Password = "wrong"
+
+	_, err := client.CallAPIMethod("ListAccounts", nil)
+	assert.True(t, errors.Is(err, element.ErrUnauthorized), "expected ErrUnauthorized, got %v", err)
+}
+
+func TestNegotiateAPIVersion(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	server.APIVersions = []string{"1.0", "8.0", "9.0"}
+	client := newTestClient(server)
+
+	version, err := client.NegotiateAPIVersion(client.Context(), "")
+	assert.NoError(t, err)
+	assert.Equal(t, "9.0", version)
+}
+
+func TestISCSISessions(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	client := newTestClient(server)
+
+	account, err := client.AddAccount(element.AddAccountRequest{Username: "tenant"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	volume, err := client.CreateVolume(element.CreateVolumeRequest{
+		Name:      "data",
+		AccountID: account.AccountID,
+		TotalSize: 1073741824,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "readWrite", volume.Volume.Access)
+
+	// access can only be set with ModifyVolume at the API levels the fake models
+	_, err = client.CallAPIMethod("CreateVolume", map[string]interface{}{
+		"name":       "locked",
+		"accountID":  account.AccountID,
+		"totalSize":  1073741824,
+		"enable512e": true,
+		"access":     "locked",
+	})
+	assert.True(t, errors.Is(err, element.ErrInvalidParameter), "%v", err)
+
+	_, err = server.ConnectISCSISession(volume.VolumeID+1, "iqn.1998-01.com.vmware:host-1")
+	assert.Error(t, err)
+
+	id, err := server.ConnectISCSISession(volume.VolumeID, "iqn.1998-01.com.vmware:host-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sessions, err := client.ListISCSISessions(element.ListISCSISessionsRequest{})
+	assert.NoError(t, err)
+	if assert.Len(t, sessions.Sessions, 1) {
+		assert.Equal(t, volume.VolumeID, sessions.Sessions[0].VolumeID)
+		assert.Equal(t, account.AccountID, sessions.Sessions[0].AccountID)
+		assert.Equal(t, volume.Volume.Iqn, sessions.Sessions[0].TargetName)
+	}
+
+	server.DisconnectISCSISession(id)
+	sessions, err = client.ListISCSISessions(element.ListISCSISessionsRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, sessions.Sessions)
+}
+
+func TestRestoreDeletedVolume(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	client := newTestClient(server)
+
+	account, err := client.AddAccount(element.AddAccountRequest{Username: "tenant"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	volume, err := client.CreateVolume(element.CreateVolumeRequest{
+		Name:      "data",
+		AccountID: account.AccountID,
+		TotalSize: 1073741824,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.DeleteVolume(element.DeleteVolumeRequest{VolumeID: volume.VolumeID})
+	assert.NoError(t, err)
+
+	deleted, err := client.ListDeletedVolumes(element.ListDeletedVolumesRequest{})
+	assert.NoError(t, err)
+	if assert.Len(t, deleted.Volumes, 1) {
+		assert.Equal(t, volume.VolumeID, deleted.Volumes[0].VolumeID)
+		assert.Equal(t, "deleted", deleted.Volumes[0].Status)
+		assert.NotEmpty(t, deleted.Volumes[0].PurgeTime)
+	}
+
+	_, err = client.RestoreDeletedVolume(element.RestoreDeletedVolumeRequest{VolumeID: volume.VolumeID})
+	assert.NoError(t, err)
+
+	v, err := client.GetVolumeByID(strconv.Itoa(volume.VolumeID))
+	assert.NoError(t, err)
+	assert.Equal(t, "active", v.Status)
+	assert.Empty(t, v.PurgeTime)
+
+	deleted, err = client.ListDeletedVolumes(element.ListDeletedVolumesRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, deleted.Volumes)
+
+	_, err = client.RestoreDeletedVolume(element.RestoreDeletedVolumeRequest{VolumeID: volume.VolumeID})
+	assert.Error(t, err)
+}
+
+func TestCloneVolume(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	server.AsyncPolls = 2
+	client := newTestClient(server)
+	client.AsyncPollInterval = time.Millisecond
+
+	account, err := client.AddAccount(element.AddAccountRequest{Username: "tenant"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	source, err := client.CreateVolume(element.CreateVolumeRequest{
+		Name:       "golden",
+		AccountID:  account.AccountID,
+		TotalSize:  1073741824,
+		Enable512e: true,
+		QoS:        &element.QoS{MaxIOPS: 2000},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.CloneVolume(element.CloneVolumeRequest{VolumeID: source.VolumeID, Name: "small", NewSize: 1000000000})
+	assert.Error(t, err)
+
+	enable512e := false
+	clone, err := client.CloneVolume(element.CloneVolumeRequest{
+		VolumeID:   source.VolumeID,
+		Name:       "clone",
+		NewSize:    2147483648,
+		Access:     "readOnly",
+		Enable512e: &enable512e,
+		Attributes: map[string]interface{}{"source": "golden"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result struct {
+		CloneID  int `json:"cloneID"`
+		VolumeID int `json:"volumeID"`
+	}
+	assert.NoError(t, client.WaitForAsyncResult(clone.AsyncHandle, &result))
+	assert.Equal(t, clone.CloneID, result.CloneID)
+	assert.Equal(t, clone.VolumeID, result.VolumeID)
+
+	// Waiting releases the result of the finished clone
+	handles, err := client.ListAsyncResults(element.ListAsyncResultsRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, handles.AsyncHandles)
+
+	v, err := client.GetVolumeByID(strconv.Itoa(clone.VolumeID))
+	assert.NoError(t, err)
+	assert.Equal(t, "clone", v.Name)
+	assert.Equal(t, account.AccountID, v.AccountID)
+	assert.Equal(t, 2147483648, v.TotalSize)
+	assert.Equal(t, "readOnly", v.Access)
+	assert.False(t, v.Enable512e)
+	assert.Equal(t, 2000, v.QoS.MaxIOPS)
+	assert.Equal(t, map[string]interface{}{"source": "golden"}, v.Attributes)
+
+	server.FailAsyncResults("xCloneFailed")
+	clone, err = client.CloneVolume(element.CloneVolumeRequest{VolumeID: source.VolumeID, Name: "failed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = client.WaitForAsyncResult(clone.AsyncHandle, nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "xCloneFailed")
+	}
+}
+
+func TestSnapshots(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	client := newTestClient(server)
+
+	account, err := client.AddAccount(element.AddAccountRequest{Username: "tenant"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	volume, err := client.CreateVolume(element.CreateVolumeRequest{
+		Name:      "data",
+		AccountID: account.AccountID,
+		TotalSize: 1073741824,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.CreateSnapshot(element.CreateSnapshotRequest{VolumeID: volume.VolumeID, Retention: "1 day"})
+	assert.Error(t, err)
+
+	snapshot, err := client.CreateSnapshot(element.CreateSnapshotRequest{
+		VolumeID:   volume.VolumeID,
+		Name:       "nightly",
+		Retention:  "24:00:00",
+		Attributes: map[string]interface{}{"job": "nightly"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, snapshot.Checksum, snapshot.Snapshot.Checksum)
+	assert.NotEmpty(t, snapshot.Snapshot.ExpirationTime)
+
+	list, err := client.ListSnapshots(element.ListSnapshotsRequest{SnapshotID: snapshot.SnapshotID})
+	assert.NoError(t, err)
+	if assert.Len(t, list.Snapshots, 1) {
+		assert.Equal(t, "nightly", list.Snapshots[0].Name)
+		assert.Equal(t, volume.VolumeID, list.Snapshots[0].VolumeID)
+		assert.Equal(t, map[string]interface{}{"job": "nightly"}, list.Snapshots[0].Attributes)
+	}
+
+	enable := true
+	modified, err := client.ModifySnapshot(element.ModifySnapshotRequest{
+		SnapshotID:              snapshot.SnapshotID,
+		ExpirationTime:          "2030-01-01T00:00:00Z",
+		EnableRemoteReplication: &enable,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "2030-01-01T00:00:00Z", modified.Snapshot.ExpirationTime)
+	assert.True(t, modified.Snapshot.EnableRemoteReplication)
+
+	clone, err := client.CloneVolume(element.CloneVolumeRequest{VolumeID: volume.VolumeID, Name: "restored", SnapshotID: snapshot.SnapshotID})
+	assert.NoError(t, err)
+	assert.NotZero(t, clone.VolumeID)
+
+	_, err = client.DeleteSnapshot(element.DeleteSnapshotRequest{SnapshotID: snapshot.SnapshotID})
+	assert.NoError(t, err)
+	list, err = client.ListSnapshots(element.ListSnapshotsRequest{VolumeID: volume.VolumeID})
+	assert.NoError(t, err)
+	assert.Empty(t, list.Snapshots)
+
+	_, err = client.DeleteSnapshot(element.DeleteSnapshotRequest{SnapshotID: snapshot.SnapshotID})
+	assert.True(t, errors.Is(err, element.ErrNotFound), "expected ErrNotFound, got %v", err)
+}
+
+func TestGroupSnapshots(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	client := newTestClient(server)
+
+	account, err := client.AddAccount(element.AddAccountRequest{Username: "tenant"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var volumes []int
+	for _, name := range []string{"data", "log"} {
+		volume, err := client.CreateVolume(element.CreateVolumeRequest{
+			Name:      name,
+			AccountID: account.AccountID,
+			TotalSize: 1073741824,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		volumes = append(volumes, volume.VolumeID)
+	}
+
+	_, err = client.CreateGroupSnapshot(element.CreateGroupSnapshotRequest{Volumes: []int{volumes[0], 999}})
+	assert.True(t, errors.Is(err, element.ErrNotFound), "expected ErrNotFound, got %v", err)
+
+	group, err := client.CreateGroupSnapshot(element.CreateGroupSnapshotRequest{
+		Volumes:    volumes,
+		Name:       "consistent",
+		Retention:  "24:00:00",
+		Attributes: map[string]interface{}{"app": "db"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, group.Members, 2) {
+		assert.Equal(t, volumes[0], group.Members[0].VolumeID)
+		assert.Equal(t, volumes[1], group.Members[1].VolumeID)
+	}
+
+	list, err := client.ListGroupSnapshots(element.ListGroupSnapshotsRequest{Volumes: volumes[1:]})
+	assert.NoError(t, err)
+	if assert.Len(t, list.GroupSnapshots, 1) {
+		assert.Equal(t, "consistent", list.GroupSnapshots[0].Name)
+		assert.Equal(t, map[string]interface{}{"app": "db"}, list.GroupSnapshots[0].Attributes)
+		if assert.Len(t, list.GroupSnapshots[0].Members, 2) {
+			assert.Equal(t, group.GroupSnapshotID, list.GroupSnapshots[0].Members[0].GroupID)
+		}
+	}
+
+	_, err = client.ModifyGroupSnapshot(element.ModifyGroupSnapshotRequest{
+		GroupSnapshotID: group.GroupSnapshotID,
+		ExpirationTime:  "2030-01-01T00:00:00Z",
+	})
+	assert.NoError(t, err)
+	snapshots, err := client.ListSnapshots(element.ListSnapshotsRequest{SnapshotID: group.Members[1].SnapshotID})
+	assert.NoError(t, err)
+	if assert.Len(t, snapshots.Snapshots, 1) {
+		assert.Equal(t, "2030-01-01T00:00:00Z", snapshots.Snapshots[0].ExpirationTime)
+	}
+
+	_, err = client.DeleteGroupSnapshot(element.DeleteGroupSnapshotRequest{GroupSnapshotID: group.GroupSnapshotID, SaveMembers: true})
+	assert.NoError(t, err)
+	snapshots, err = client.ListSnapshots(element.ListSnapshotsRequest{})
+	assert.NoError(t, err)
+	if assert.Len(t, snapshots.Snapshots, 2) {
+		assert.Zero(t, snapshots.Snapshots[0].GroupID)
+	}
+
+	group, err = client.CreateGroupSnapshot(element.CreateGroupSnapshotRequest{Volumes: volumes})
+	assert.NoError(t, err)
+	_, err = client.DeleteGroupSnapshot(element.DeleteGroupSnapshotRequest{GroupSnapshotID: group.GroupSnapshotID})
+	assert.NoError(t, err)
+	snapshots, err = client.ListSnapshots(element.ListSnapshotsRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, snapshots.Snapshots, 2)
+
+	_, err = client.DeleteGroupSnapshot(element.DeleteGroupSnapshotRequest{GroupSnapshotID: group.GroupSnapshotID})
+	assert.True(t, errors.Is(err, element.ErrNotFound), "expected ErrNotFound, got %v", err)
+}
+
+func TestRollback(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	client := newTestClient(server)
+
+	account, err := client.AddAccount(element.AddAccountRequest{Username: "tenant"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var volumes []int
+	for _, name := range []string{"data", "log"} {
+		volume, err := client.CreateVolume(element.CreateVolumeRequest{
+			Name:      name,
+			AccountID: account.AccountID,
+			TotalSize: 1073741824,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		volumes = append(volumes, volume.VolumeID)
+	}
+
+	snapshot, err := client.CreateSnapshot(element.CreateSnapshotRequest{VolumeID: volumes[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.RollbackToSnapshot(element.RollbackToSnapshotRequest{VolumeID: volumes[1], SnapshotID: snapshot.SnapshotID})
+	assert.True(t, errors.Is(err, element.ErrNotFound), "expected ErrNotFound, got %v", err)
+
+	rollback, err := client.RollbackToSnapshot(element.RollbackToSnapshotRequest{VolumeID: volumes[0], SnapshotID: snapshot.SnapshotID})
+	assert.NoError(t, err)
+	assert.Zero(t, rollback.SnapshotID)
+	assert.Nil(t, rollback.Snapshot)
+
+	rollback, err = client.RollbackToSnapshot(element.RollbackToSnapshotRequest{
+		VolumeID:         volumes[0],
+		SnapshotID:       snapshot.SnapshotID,
+		SaveCurrentState: true,
+		Name:             "before-rollback",
+	})
+	assert.NoError(t, err)
+	if assert.NotNil(t, rollback.Snapshot) {
+		assert.Equal(t, rollback.SnapshotID, rollback.Snapshot.SnapshotID)
+		assert.Equal(t, "before-rollback", rollback.Snapshot.Name)
+	}
+
+	group, err := client.CreateGroupSnapshot(element.CreateGroupSnapshotRequest{Volumes: volumes})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	groupRollback, err := client.RollbackToGroupSnapshot(element.RollbackToGroupSnapshotRequest{
+		GroupSnapshotID:  group.GroupSnapshotID,
+		SaveCurrentState: true,
+	})
+	assert.NoError(t, err)
+	assert.NotEqual(t, group.GroupSnapshotID, groupRollback.GroupSnapshotID)
+	assert.Len(t, groupRollback.Members, 2)
+
+	list, err := client.ListGroupSnapshots(element.ListGroupSnapshotsRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, list.GroupSnapshots, 2)
+
+	_, err = client.RollbackToGroupSnapshot(element.RollbackToGroupSnapshotRequest{GroupSnapshotID: 999})
+	assert.True(t, errors.Is(err, element.ErrNotFound), "expected ErrNotFound, got %v", err)
+}
+
+func TestSchedules(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	client := newTestClient(server)
+
+	account, err := client.AddAccount(element.AddAccountRequest{Username: "tenant"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	volume, err := client.CreateVolume(element.CreateVolumeRequest{
+		Name:      "data",
+		AccountID: account.AccountID,
+		TotalSize: 1073741824,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := element.CreateScheduleRequest{
+		ScheduleName: "hourly",
+		ScheduleType: "Snapshot",
+		ScheduleInfo: element.ScheduleInfo{VolumeID: volume.VolumeID, Retention: "24:00:00"},
+		Attributes:   map[string]interface{}{"frequency": "Days Of Week"},
+		Hours:        1,
+		Recurring:    true,
+	}
+	_, err = client.CreateSchedule(request)
+	assert.Error(t, err, "weekdays are required")
+
+	request.Attributes = map[string]interface{}{"frequency": "Time Interval"}
+	created, err := client.CreateSchedule(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, created.ScheduleID, created.Schedule.ScheduleID)
+
+	schedule, err := client.GetSchedule(element.GetScheduleRequest{ScheduleID: created.ScheduleID})
+	assert.NoError(t, err)
+	assert.Equal(t, "hourly", schedule.Schedule.ScheduleName)
+	assert.Equal(t, volume.VolumeID, schedule.Schedule.ScheduleInfo.VolumeID)
+	assert.Equal(t, map[string]interface{}{"frequency": "Time Interval"}, schedule.Schedule.Attributes)
+	assert.True(t, schedule.Schedule.Recurring)
+
+	paused := true
+	hours := 0
+	modified, err := client.ModifySchedule(element.ModifyScheduleRequest{
+		ScheduleID: created.ScheduleID,
+		Attributes: map[string]interface{}{"frequency": "Days Of Month"},
+		Hours:      &hours,
+		Monthdays:  []int{1, 15},
+		Paused:     &paused,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 15}, modified.Schedule.Monthdays)
+	assert.True(t, modified.Schedule.Paused)
+	assert.Equal(t, "24:00:00", modified.Schedule.ScheduleInfo.Retention)
+
+	_, err = client.ModifySchedule(element.ModifyScheduleRequest{
+		ScheduleID:   created.ScheduleID,
+		ScheduleInfo: &element.ScheduleInfo{Volumes: []int{volume.VolumeID, 999}},
+	})
+	assert.True(t, errors.Is(err, element.ErrNotFound), "expected ErrNotFound, got %v", err)
+
+	deleted := true
+	_, err = client.ModifySchedule(element.ModifyScheduleRequest{ScheduleID: created.ScheduleID, ToBeDeleted: &deleted})
+	assert.NoError(t, err)
+
+	list, err := client.ListSchedules(element.ListSchedulesRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, list.Schedules)
+
+	_, err = client.GetSchedule(element.GetScheduleRequest{ScheduleID: created.ScheduleID})
+	assert.True(t, errors.Is(err, element.ErrNotFound), "expected ErrNotFound, got %v", err)
+}
+
+func TestQoSPolicies(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	client := newTestClient(server)
+
+	account, err := client.AddAccount(element.AddAccountRequest{Username: "tenant"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy, err := client.CreateQoSPolicy(element.CreateQoSPolicyRequest{
+		Name: "gold",
+		QoS:  element.QoS{MinIOPS: 1000, MaxIOPS: 5000, BurstIOPS: 8000},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	policyID := policy.QoSPolicy.QoSPolicyID
+
+	_, err = client.CreateQoSPolicy(element.CreateQoSPolicyRequest{Name: "gold", QoS: element.QoS{MinIOPS: 100}})
+	assert.True(t, errors.Is(err, element.ErrAlreadyExists), "expected ErrAlreadyExists, got %v", err)
+
+	_, err = client.CreateVolume(element.CreateVolumeRequest{
+		Name:        "data",
+		AccountID:   account.AccountID,
+		TotalSize:   1073741824,
+		QoS:         &element.QoS{MinIOPS: 100},
+		QoSPolicyID: policyID,
+	})
+	assert.Error(t, err)
+
+	volume, err := client.CreateVolume(element.CreateVolumeRequest{
+		Name:        "data",
+		AccountID:   account.AccountID,
+		TotalSize:   1073741824,
+		QoSPolicyID: policyID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.NotNil(t, volume.Volume.QoSPolicyID) {
+		assert.Equal(t, policyID, *volume.Volume.QoSPolicyID)
+	}
+	assert.Equal(t, 1000, volume.Volume.QoS.MinIOPS)
+
+	_, err = client.ModifyQoSPolicy(element.ModifyQoSPolicyRequest{
+		QoSPolicyID: policyID,
+		QoS:         &element.QoS{MinIOPS: 2000, MaxIOPS: 6000, BurstIOPS: 9000},
+	})
+	assert.NoError(t, err)
+	list, err := client.ListVolumes(element.ListVolumesRequest{VolumeIDs: []int{volume.VolumeID}})
+	assert.NoError(t, err)
+	if assert.Len(t, list.Volumes, 1) {
+		assert.Equal(t, 2000, list.Volumes[0].QoS.MinIOPS)
+	}
+
+	policies, err := client.ListQoSPolicies(element.ListQoSPoliciesRequest{})
+	assert.NoError(t, err)
+	if assert.Len(t, policies.QoSPolicies, 1) {
+		assert.Equal(t, []int{volume.VolumeID}, policies.QoSPolicies[0].VolumeIDs)
+	}
+
+	_, err = client.ModifyVolume(element.ModifyVolumeRequest{VolumeID: volume.VolumeID, QoS: &element.QoS{MinIOPS: 100}})
+	assert.Error(t, err)
+
+	_, err = client.DeleteQoSPolicy(element.DeleteQoSPolicyRequest{QoSPolicyID: policyID})
+	assert.True(t, errors.Is(err, element.ErrInUse), "expected ErrInUse, got %v", err)
+
+	associate := false
+	modified, err := client.ModifyVolume(element.ModifyVolumeRequest{
+		VolumeID:               volume.VolumeID,
+		QoS:                    &element.QoS{MinIOPS: 100},
+		AssociateWithQoSPolicy: &associate,
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, modified.Volume.QoSPolicyID)
+	assert.Equal(t, 100, modified.Volume.QoS.MinIOPS)
+
+	_, err = client.DeleteQoSPolicy(element.DeleteQoSPolicyRequest{QoSPolicyID: policyID})
+	assert.NoError(t, err)
+	_, err = client.DeleteQoSPolicy(element.DeleteQoSPolicyRequest{QoSPolicyID: policyID})
+	assert.True(t, errors.Is(err, element.ErrNotFound), "expected ErrNotFound, got %v", err)
+}
+
+func TestLimits(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	client := newTestClient(server)
+
+	limits, err := client.GetLimits(element.GetLimitsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 50, limits.VolumeMinIOPSMin)
+	assert.Equal(t, 200000, limits.VolumeBurstIOPSMax)
+	assert.Equal(t, 12, limits.SecretLengthMin)
+	assert.Equal(t, 16, limits.SecretLengthMax)
+
+	account, err := client.AddAccount(element.AddAccountRequest{Username: "tenant"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The fake enforces the limits it reports
+	_, err = client.CreateVolume(element.CreateVolumeRequest{
+		Name:       "data",
+		AccountID:  account.AccountID,
+		TotalSize:  limits.VolumeSizeMin,
+		Enable512e: true,
+		QoS:        &element.QoS{MinIOPS: limits.VolumeMinIOPSMax + 1, MaxIOPS: limits.VolumeMaxIOPSMax, BurstIOPS: limits.VolumeBurstIOPSMax},
+	})
+	assert.True(t, errors.Is(err, element.ErrInvalidParameter), "%v", err)
+
+	_, err = client.CreateVolume(element.CreateVolumeRequest{
+		Name:       "data",
+		AccountID:  account.AccountID,
+		TotalSize:  limits.VolumeSizeMin,
+		Enable512e: true,
+		QoS:        &element.QoS{MinIOPS: limits.VolumeMinIOPSMax, MaxIOPS: limits.VolumeMaxIOPSMax, BurstIOPS: limits.VolumeBurstIOPSMax},
+	})
+	assert.NoError(t, err)
+}
+
+func TestAccountSettings(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	client := newTestClient(server)
+
+	enableChap := false
+	account, err := client.AddAccount(element.AddAccountRequest{Username: "tenant", EnableChap: &enableChap})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "active", account.Account.Status)
+	assert.False(t, account.Account.EnableChap)
+	assert.Equal(t, "00000000-0000-0000-0000-000000000000", account.Account.StorageContainerID)
+
+	enableChap = true
+	modified, err := client.ModifyAccount(element.ModifyAccountRequest{
+		AccountID:  account.AccountID,
+		Status:     "locked",
+		EnableChap: &enableChap,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "locked", modified.Account.Status)
+	assert.True(t, modified.Account.EnableChap)
+
+	_, err = client.ModifyAccount(element.ModifyAccountRequest{AccountID: account.AccountID, Status: "disabled"})
+	assert.True(t, errors.Is(err, element.ErrInvalidParameter), "%v", err)
+}
+
+func TestListVolumesForAccount(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	client := newTestClient(server)
+
+	account, err := client.AddAccount(element.AddAccountRequest{Username: "tenant"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []int
+	for _, name := range []string{"data", "log"} {
+		volume, err := client.CreateVolume(element.CreateVolumeRequest{
+			Name:       name,
+			AccountID:  account.AccountID,
+			TotalSize:  1073741824,
+			Enable512e: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, volume.VolumeID)
+	}
+
+	if _, err := client.DeleteVolume(element.DeleteVolumeRequest{VolumeID: ids[1]}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Deleted volumes are listed until they are purged, and keep the account from being removed
+	list, err := client.ListVolumesForAccount(element.ListVolumesForAccountRequest{AccountID: account.AccountID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, list.Volumes, 2) {
+		assert.Equal(t, "active", list.Volumes[0].Status)
+		assert.Equal(t, "deleted", list.Volumes[1].Status)
+	}
+
+	if _, err := client.DeleteVolume(element.DeleteVolumeRequest{VolumeID: ids[0]}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.RemoveAccount(element.RemoveAccountRequest{AccountID: account.AccountID})
+	assert.True(t, errors.Is(err, element.ErrInUse), "%v", err)
+
+	for _, id := range ids {
+		if _, err := client.PurgeDeletedVolume(element.PurgeDeletedVolumeRequest{VolumeID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err = client.RemoveAccount(element.RemoveAccountRequest{AccountID: account.AccountID})
+	assert.NoError(t, err)
+
+	_, err = client.ListVolumesForAccount(element.ListVolumesForAccountRequest{AccountID: account.AccountID})
+	assert.True(t, errors.Is(err, element.ErrNotFound), "%v", err)
+}
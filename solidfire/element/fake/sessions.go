@@ -0,0 +1,74 @@
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+func init() {
+	methods["ListISCSISessions"] = listISCSISessions
+}
+
+// ISCSISession is an active iSCSI session as returned by the API
+type ISCSISession struct {
+	SessionID     int    `json:"sessionID"`
+	VolumeID      int    `json:"volumeID"`
+	AccountID     int    `json:"accountID"`
+	InitiatorName string `json:"initiatorName"`
+	InitiatorIP   string `json:"initiatorIP"`
+	TargetName    string `json:"targetName"`
+	TargetIP      string `json:"targetIP"`
+	NodeID        int    `json:"nodeID"`
+	ServiceID     int    `json:"serviceID"`
+	CreateTime    string `json:"createTime"`
+}
+
+// ConnectISCSISession simulates an initiator logging in to a volume and returns the ID of the new session.
+// The volume must exist.
+func (s *Server) ConnectISCSISession(volumeID int, initiatorName string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	volume, err := s.volume(volumeID)
+	if err != nil {
+		return 0, err
+	}
+
+	id := s.newID("session")
+	s.sessions[id] = &ISCSISession{
+		SessionID:     id,
+		VolumeID:      volume.VolumeID,
+		AccountID:     volume.AccountID,
+		InitiatorName: initiatorName,
+		InitiatorIP:   fmt.Sprintf("10.117.1.%d:3260", id%250+1),
+		TargetName:    volume.Iqn,
+		TargetIP:      "10.117.0.10:3260",
+		NodeID:        1,
+		ServiceID:     1,
+		CreateTime:    s.timestamp(),
+	}
+	return id, nil
+}
+
+// DisconnectISCSISession simulates an initiator logging out of the session with the given ID
+func (s *Server) DisconnectISCSISession(sessionID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, sessionID)
+}
+
+func listISCSISessions(s *Server, params json.RawMessage) (interface{}, error) {
+	var ids []int
+	for id := range s.sessions {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	sessions := []*ISCSISession{}
+	for _, id := range ids {
+		sessions = append(sessions, s.sessions[id])
+	}
+
+	return map[string]interface{}{"sessions": sessions}, nil
+}
@@ -0,0 +1,309 @@
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+func init() {
+	methods["CreateSnapshot"] = createSnapshot
+	methods["ListSnapshots"] = listSnapshots
+	methods["ModifySnapshot"] = modifySnapshot
+	methods["DeleteSnapshot"] = deleteSnapshot
+	methods["RollbackToSnapshot"] = rollbackToSnapshot
+}
+
+// Snapshot is an Element snapshot as returned by the API
+type Snapshot struct {
+	SnapshotID              int                    `json:"snapshotID"`
+	VolumeID                int                    `json:"volumeID"`
+	Name                    string                 `json:"name"`
+	Checksum                string                 `json:"checksum"`
+	EnableRemoteReplication bool                   `json:"enableRemoteReplication"`
+	ExpirationReason        string                 `json:"expirationReason"`
+	ExpirationTime          *string                `json:"expirationTime"`
+	Status                  string                 `json:"status"`
+	SnapshotUUID            string                 `json:"snapshotUUID"`
+	TotalSize               int                    `json:"totalSize"`
+	GroupID                 int                    `json:"groupID"`
+	GroupSnapshotUUID       string                 `json:"groupSnapshotUUID"`
+	CreateTime              string                 `json:"createTime"`
+	SnapMirrorLabel         string                 `json:"snapMirrorLabel"`
+	Attributes              map[string]interface{} `json:"attributes"`
+}
+
+var retentionPattern = regexp.MustCompile(`^(\d+):([0-5]\d):([0-5]\d)$`)
+
+// parseRetention parses a retention period in the HH:mm:ss format of the API
+func parseRetention(retention string) (time.Duration, error) {
+	m := retentionPattern.FindStringSubmatch(retention)
+	if m == nil {
+		return 0, newError("xInvalidParameter", "Invalid retention %v, expected HH:mm:ss", retention)
+	}
+	hours, _ := strconv.Atoi(m[1])
+	minutes, _ := strconv.Atoi(m[2])
+	seconds, _ := strconv.Atoi(m[3])
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second, nil
+}
+
+func (s *Server) snapshot(id int) (*Snapshot, error) {
+	s.purgeExpiredSnapshots()
+
+	snapshot, ok := s.snapshots[id]
+	if !ok {
+		return nil, newError("xSnapshotIDDoesNotExist", "Snapshot %v does not exist", id)
+	}
+	return snapshot, nil
+}
+
+// newSnapshot takes a snapshot of volume that expires after retention, or never if retention is zero
+func (s *Server) newSnapshot(volume *Volume, name string, retention time.Duration, groupID int, groupUUID string) *Snapshot {
+	id := s.newID("snapshot")
+	if name == "" {
+		name = s.now().UTC().Format(time.RFC3339)
+	}
+
+	snapshot := &Snapshot{
+		SnapshotID:        id,
+		VolumeID:          volume.VolumeID,
+		Name:              name,
+		Checksum:          fmt.Sprintf("0x%08x", id*2654435761%(1<<32)),
+		ExpirationReason:  "None",
+		Status:            "done",
+		SnapshotUUID:      fmt.Sprintf("00000000-0000-4000-8000-%012x", id),
+		TotalSize:         volume.TotalSize,
+		GroupID:           groupID,
+		GroupSnapshotUUID: groupUUID,
+		CreateTime:        s.timestamp(),
+		Attributes:        map[string]interface{}{},
+	}
+	if retention > 0 {
+		expiration := s.now().Add(retention).UTC().Format(time.RFC3339)
+		snapshot.ExpirationTime = &expiration
+		snapshot.ExpirationReason = "Retention"
+	}
+
+	s.snapshots[id] = snapshot
+	return snapshot
+}
+
+// purgeExpiredSnapshots removes snapshots whose expiration time has passed, as the cluster does
+func (s *Server) purgeExpiredSnapshots() {
+	now := s.now()
+	for id, snapshot := range s.snapshots {
+		if snapshot.ExpirationTime == nil {
+			continue
+		}
+		expiration, err := time.Parse(time.RFC3339, *snapshot.ExpirationTime)
+		if err == nil && now.After(expiration) {
+			delete(s.snapshots, id)
+		}
+	}
+	s.purgeEmptyGroupSnapshots()
+}
+
+// deleteVolumeSnapshots removes the snapshots of a purged volume
+func (s *Server) deleteVolumeSnapshots(volumeID int) {
+	for id, snapshot := range s.snapshots {
+		if snapshot.VolumeID == volumeID {
+			delete(s.snapshots, id)
+		}
+	}
+	s.purgeEmptyGroupSnapshots()
+}
+
+func createSnapshot(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		VolumeID                int                    `json:"volumeID"`
+		SnapshotID              int                    `json:"snapshotID"`
+		Name                    string                 `json:"name"`
+		EnableRemoteReplication bool                   `json:"enableRemoteReplication"`
+		Retention               string                 `json:"retention"`
+		SnapMirrorLabel         string                 `json:"snapMirrorLabel"`
+		Attributes              map[string]interface{} `json:"attributes"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	volume, err := s.volume(p.VolumeID)
+	if err != nil {
+		return nil, err
+	}
+	if volume.Status != "active" {
+		return nil, newError("xVolumeIDDoesNotExist", "Volume %v is deleted", p.VolumeID)
+	}
+
+	if p.SnapshotID != 0 {
+		source, err := s.snapshot(p.SnapshotID)
+		if err != nil {
+			return nil, err
+		}
+		if source.VolumeID != volume.VolumeID {
+			return nil, newError("xSnapshotIDDoesNotExist", "Snapshot %v does not belong to volume %v", p.SnapshotID, p.VolumeID)
+		}
+	}
+
+	var retention time.Duration
+	if p.Retention != "" {
+		if retention, err = parseRetention(p.Retention); err != nil {
+			return nil, err
+		}
+	}
+
+	snapshot := s.newSnapshot(volume, p.Name, retention, 0, "")
+	snapshot.EnableRemoteReplication = p.EnableRemoteReplication
+	snapshot.SnapMirrorLabel = p.SnapMirrorLabel
+	if p.Attributes != nil {
+		snapshot.Attributes = p.Attributes
+	}
+
+	return map[string]interface{}{
+		"snapshotID": snapshot.SnapshotID,
+		"checksum":   snapshot.Checksum,
+		"snapshot":   snapshot,
+	}, nil
+}
+
+func listSnapshots(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		VolumeID   int `json:"volumeID"`
+		SnapshotID int `json:"snapshotID"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	if p.VolumeID != 0 {
+		if _, err := s.volume(p.VolumeID); err != nil {
+			return nil, err
+		}
+	}
+
+	s.purgeExpiredSnapshots()
+
+	var ids []int
+	for id, snapshot := range s.snapshots {
+		if p.VolumeID != 0 && snapshot.VolumeID != p.VolumeID {
+			continue
+		}
+		if p.SnapshotID != 0 && id != p.SnapshotID {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	snapshots := []*Snapshot{}
+	for _, id := range ids {
+		snapshots = append(snapshots, s.snapshots[id])
+	}
+
+	return map[string]interface{}{"snapshots": snapshots}, nil
+}
+
+func modifySnapshot(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		SnapshotID              int     `json:"snapshotID"`
+		Name                    string  `json:"name"`
+		ExpirationTime          *string `json:"expirationTime"`
+		EnableRemoteReplication *bool   `json:"enableRemoteReplication"`
+		SnapMirrorLabel         *string `json:"snapMirrorLabel"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	snapshot, err := s.snapshot(p.SnapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.ExpirationTime != nil {
+		if _, err := time.Parse(time.RFC3339, *p.ExpirationTime); err != nil {
+			return nil, newError("xInvalidParameter", "Invalid expirationTime %v", *p.ExpirationTime)
+		}
+	}
+
+	if p.Name != "" {
+		snapshot.Name = p.Name
+	}
+	if p.ExpirationTime != nil {
+		expiration := *p.ExpirationTime
+		snapshot.ExpirationTime = &expiration
+		snapshot.ExpirationReason = "Retention"
+	}
+	if p.EnableRemoteReplication != nil {
+		snapshot.EnableRemoteReplication = *p.EnableRemoteReplication
+	}
+	if p.SnapMirrorLabel != nil {
+		snapshot.SnapMirrorLabel = *p.SnapMirrorLabel
+	}
+
+	return map[string]interface{}{"snapshot": snapshot}, nil
+}
+
+func deleteSnapshot(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		SnapshotID int `json:"snapshotID"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.snapshot(p.SnapshotID); err != nil {
+		return nil, err
+	}
+
+	delete(s.snapshots, p.SnapshotID)
+	s.purgeEmptyGroupSnapshots()
+	return map[string]interface{}{}, nil
+}
+
+func rollbackToSnapshot(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		VolumeID         int                    `json:"volumeID"`
+		SnapshotID       int                    `json:"snapshotID"`
+		SaveCurrentState bool                   `json:"saveCurrentState"`
+		Name             string                 `json:"name"`
+		Attributes       map[string]interface{} `json:"attributes"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	volume, err := s.volume(p.VolumeID)
+	if err != nil {
+		return nil, err
+	}
+	if volume.Status != "active" {
+		return nil, newError("xVolumeIDDoesNotExist", "Volume %v is deleted", p.VolumeID)
+	}
+
+	snapshot, err := s.snapshot(p.SnapshotID)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot.VolumeID != volume.VolumeID {
+		return nil, newError("xSnapshotIDDoesNotExist", "Snapshot %v does not belong to volume %v", p.SnapshotID, p.VolumeID)
+	}
+
+	if !p.SaveCurrentState {
+		return map[string]interface{}{"checksum": snapshot.Checksum, "snapshotID": 0, "snapshot": nil}, nil
+	}
+
+	saved := s.newSnapshot(volume, p.Name, 0, 0, "")
+	if p.Attributes != nil {
+		saved.Attributes = p.Attributes
+	}
+
+	return map[string]interface{}{
+		"checksum":   saved.Checksum,
+		"snapshotID": saved.SnapshotID,
+		"snapshot":   saved,
+	}, nil
+}
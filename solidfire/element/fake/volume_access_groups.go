@@ -0,0 +1,254 @@
+package fake
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+func init() {
+	methods["CreateVolumeAccessGroup"] = createVolumeAccessGroup
+	methods["ListVolumeAccessGroups"] = listVolumeAccessGroups
+	methods["ModifyVolumeAccessGroup"] = modifyVolumeAccessGroup
+	methods["DeleteVolumeAccessGroup"] = deleteVolumeAccessGroup
+}
+
+// VolumeAccessGroup is an Element volume access group as returned by the API
+type VolumeAccessGroup struct {
+	VolumeAccessGroupID int                    `json:"volumeAccessGroupID"`
+	Name                string                 `json:"name"`
+	Initiators          []string               `json:"initiators"`
+	InitiatorIDs        []int                  `json:"initiatorIDs"`
+	Volumes             []int                  `json:"volumes"`
+	DeletedVolumes      []int                  `json:"deletedVolumes"`
+	Attributes          map[string]interface{} `json:"attributes"`
+}
+
+func (s *Server) accessGroup(id int) (*VolumeAccessGroup, error) {
+	group, ok := s.accessGroups[id]
+	if !ok {
+		return nil, newError("xVolumeAccessGroupIDDoesNotExist", "Volume access group %v does not exist", id)
+	}
+	return group, nil
+}
+
+func (s *Server) sortedAccessGroups() []*VolumeAccessGroup {
+	var ids []int
+	for id := range s.accessGroups {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	groups := make([]*VolumeAccessGroup, 0, len(ids))
+	for _, id := range ids {
+		groups = append(groups, s.accessGroups[id])
+	}
+	return groups
+}
+
+// accessGroupView fills in the fields derived from other objects
+func (s *Server) accessGroupView(group *VolumeAccessGroup) VolumeAccessGroup {
+	view := *group
+	view.Initiators = []string{}
+	for _, id := range group.InitiatorIDs {
+		view.Initiators = append(view.Initiators, s.initiators[id].InitiatorName)
+	}
+	view.DeletedVolumes = []int{}
+	return view
+}
+
+// resolveInitiators maps initiator names or IDs to initiator IDs. Unknown names are created, as the
+// cluster does when an access group is given an IQN that has no initiator object yet.
+func (s *Server) resolveInitiators(values []interface{}) ([]int, error) {
+	ids := []int{}
+	for _, value := range values {
+		switch v := value.(type) {
+		case float64:
+			if _, err := s.initiator(int(v)); err != nil {
+				return nil, err
+			}
+			ids = append(ids, int(v))
+		case string:
+			initiator := s.initiatorByName(v)
+			if initiator == nil {
+				initiator = s.newInitiator(v, "", nil)
+			}
+			ids = append(ids, initiator.InitiatorID)
+		default:
+			return nil, newError("xInvalidParameterType", "Initiators must be names or IDs")
+		}
+	}
+	return ids, nil
+}
+
+func (s *Server) validateVolumes(ids []int) error {
+	for _, id := range ids {
+		volume, err := s.volume(id)
+		if err != nil {
+			return err
+		}
+		if volume.Status != "active" {
+			return newError("xVolumeIDDoesNotExist", "Volume %v is deleted", id)
+		}
+	}
+	return nil
+}
+
+func createVolumeAccessGroup(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Name       string                 `json:"name"`
+		Initiators []interface{}          `json:"initiators"`
+		Volumes    []int                  `json:"volumes"`
+		Attributes map[string]interface{} `json:"attributes"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	if p.Name == "" {
+		return nil, newError("xMissingParameter", "name is required")
+	}
+	for _, group := range s.accessGroups {
+		if group.Name == p.Name {
+			return nil, newError("xVolumeAccessGroupExists", "Volume access group %v already exists", p.Name)
+		}
+	}
+	if err := s.validateVolumes(p.Volumes); err != nil {
+		return nil, err
+	}
+	initiatorIDs, err := s.resolveInitiators(p.Initiators)
+	if err != nil {
+		return nil, err
+	}
+
+	group := &VolumeAccessGroup{
+		VolumeAccessGroupID: s.newID("volumeAccessGroup"),
+		Name:                p.Name,
+		InitiatorIDs:        initiatorIDs,
+		Volumes:             append([]int{}, p.Volumes...),
+		Attributes:          p.Attributes,
+	}
+	if group.Attributes == nil {
+		group.Attributes = map[string]interface{}{}
+	}
+	s.accessGroups[group.VolumeAccessGroupID] = group
+
+	return map[string]interface{}{
+		"volumeAccessGroupID": group.VolumeAccessGroupID,
+		"volumeAccessGroup":   s.accessGroupView(group),
+	}, nil
+}
+
+func listVolumeAccessGroups(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		StartVolumeAccessGroupID int   `json:"startVolumeAccessGroupID"`
+		Limit                    int   `json:"limit"`
+		VolumeAccessGroups       []int `json:"volumeAccessGroups"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	groups := []VolumeAccessGroup{}
+	notFound := []int{}
+	if len(p.VolumeAccessGroups) > 0 {
+		for _, id := range p.VolumeAccessGroups {
+			if group, ok := s.accessGroups[id]; ok {
+				groups = append(groups, s.accessGroupView(group))
+			} else {
+				notFound = append(notFound, id)
+			}
+		}
+	} else {
+		for _, group := range s.sortedAccessGroups() {
+			if group.VolumeAccessGroupID < p.StartVolumeAccessGroupID {
+				continue
+			}
+			if p.Limit > 0 && len(groups) == p.Limit {
+				break
+			}
+			groups = append(groups, s.accessGroupView(group))
+		}
+	}
+
+	result := map[string]interface{}{"volumeAccessGroups": groups}
+	if len(notFound) > 0 {
+		result["volumeAccessGroupsNotFound"] = notFound
+	}
+	return result, nil
+}
+
+func modifyVolumeAccessGroup(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		VolumeAccessGroupID    int                     `json:"volumeAccessGroupID"`
+		Name                   string                  `json:"name"`
+		Initiators             *[]interface{}          `json:"initiators"`
+		Volumes                *[]int                  `json:"volumes"`
+		DeleteOrphanInitiators bool                    `json:"deleteOrphanInitiators"`
+		Attributes             *map[string]interface{} `json:"attributes"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	group, err := s.accessGroup(p.VolumeAccessGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Volumes != nil {
+		if err := s.validateVolumes(*p.Volumes); err != nil {
+			return nil, err
+		}
+	}
+	var initiatorIDs []int
+	if p.Initiators != nil {
+		if initiatorIDs, err = s.resolveInitiators(*p.Initiators); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.Name != "" {
+		group.Name = p.Name
+	}
+	if p.Volumes != nil {
+		group.Volumes = append([]int{}, *p.Volumes...)
+	}
+	if p.Initiators != nil {
+		removed := group.InitiatorIDs
+		group.InitiatorIDs = initiatorIDs
+		if p.DeleteOrphanInitiators {
+			s.deleteOrphanInitiators(removed)
+		}
+	}
+	if p.Attributes != nil && *p.Attributes != nil {
+		group.Attributes = *p.Attributes
+	}
+
+	return map[string]interface{}{"volumeAccessGroup": s.accessGroupView(group)}, nil
+}
+
+func deleteVolumeAccessGroup(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		VolumeAccessGroupID    int  `json:"volumeAccessGroupID"`
+		DeleteOrphanInitiators bool `json:"deleteOrphanInitiators"`
+		Force                  bool `json:"force"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	group, err := s.accessGroup(p.VolumeAccessGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if len(group.Volumes) > 0 && !p.Force {
+		return nil, newError("xVolumesInVolumeAccessGroup", "Volume access group %v still contains volumes", p.VolumeAccessGroupID)
+	}
+
+	delete(s.accessGroups, group.VolumeAccessGroupID)
+	if p.DeleteOrphanInitiators {
+		s.deleteOrphanInitiators(group.InitiatorIDs)
+	}
+
+	return map[string]interface{}{}, nil
+}
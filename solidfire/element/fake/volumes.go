@@ -0,0 +1,662 @@
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	mebibyte         = 1024 * 1024
+	minVolumeSize    = 1000000000
+	maxVolumeSize    = 17592186044416
+	deletedVolumeTTL = 8 * time.Hour
+
+	defaultMinIOPS     = 50
+	defaultMaxIOPS     = 15000
+	defaultBurstIOPS   = 15000
+	volumeMinIOPSMin   = 50
+	volumeMinIOPSMax   = 15000
+	volumeMaxIOPSMin   = 100
+	volumeMaxIOPSMax   = 200000
+	volumeBurstIOPSMin = 100
+	volumeBurstIOPSMax = 200000
+
+	defaultFifoSize = 24
+	maxFifoSize     = 32
+)
+
+func init() {
+	methods["CreateVolume"] = createVolume
+	methods["CloneVolume"] = cloneVolume
+	methods["ListVolumes"] = listVolumes
+	methods["ListActiveVolumes"] = listActiveVolumes
+	methods["ListDeletedVolumes"] = listDeletedVolumes
+	methods["ListVolumesForAccount"] = listVolumesForAccount
+	methods["ModifyVolume"] = modifyVolume
+	methods["DeleteVolume"] = deleteVolume
+	methods["RestoreDeletedVolume"] = restoreDeletedVolume
+	methods["PurgeDeletedVolume"] = purgeDeletedVolume
+}
+
+// QoS is the quality of service settings of a volume
+type QoS struct {
+	MinIOPS   int `json:"minIOPS"`
+	MaxIOPS   int `json:"maxIOPS"`
+	BurstIOPS int `json:"burstIOPS"`
+	BurstTime int `json:"burstTime"`
+}
+
+// Volume is an Element volume as returned by the API
+type Volume struct {
+	VolumeID           int                    `json:"volumeID"`
+	Name               string                 `json:"name"`
+	AccountID          int                    `json:"accountID"`
+	CreateTime         string                 `json:"createTime"`
+	Status             string                 `json:"status"`
+	Access             string                 `json:"access"`
+	Enable512e         bool                   `json:"enable512e"`
+	Iqn                string                 `json:"iqn"`
+	ScsiEUIDeviceID    string                 `json:"scsiEUIDeviceID"`
+	ScsiNAADeviceID    string                 `json:"scsiNAADeviceID"`
+	QoS                QoS                    `json:"qos"`
+	QoSPolicyID        *int                   `json:"qosPolicyID"`
+	TotalSize          int                    `json:"totalSize"`
+	BlockSize          int                    `json:"blockSize"`
+	VolumeAccessGroups []int                  `json:"volumeAccessGroups"`
+	VolumePairs        []interface{}          `json:"volumePairs"`
+	DeleteTime         string                 `json:"deleteTime"`
+	PurgeTime          string                 `json:"purgeTime"`
+	SliceCount         int                    `json:"sliceCount"`
+	VirtualVolumeID    *string                `json:"virtualVolumeID"`
+	FifoSize           int                    `json:"fifoSize"`
+	MinFifoSize        int                    `json:"minFifoSize"`
+	Attributes         map[string]interface{} `json:"attributes"`
+}
+
+func (s *Server) volume(id int) (*Volume, error) {
+	volume, ok := s.volumes[id]
+	if !ok {
+		return nil, newError("xVolumeIDDoesNotExist", "Volume %v does not exist", id)
+	}
+	return volume, nil
+}
+
+func (s *Server) sortedVolumes() []*Volume {
+	var ids []int
+	for id := range s.volumes {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	volumes := make([]*Volume, 0, len(ids))
+	for _, id := range ids {
+		volumes = append(volumes, s.volumes[id])
+	}
+	return volumes
+}
+
+// volumeView fills in the fields derived from other objects
+func (s *Server) volumeView(volume *Volume) Volume {
+	view := *volume
+	view.VolumeAccessGroups = []int{}
+	for _, group := range s.sortedAccessGroups() {
+		for _, id := range group.Volumes {
+			if id == volume.VolumeID {
+				view.VolumeAccessGroups = append(view.VolumeAccessGroups, group.VolumeAccessGroupID)
+			}
+		}
+	}
+	return view
+}
+
+func (s *Server) volumeViews(filter func(*Volume) bool) []Volume {
+	views := []Volume{}
+	for _, volume := range s.sortedVolumes() {
+		if filter(volume) {
+			views = append(views, s.volumeView(volume))
+		}
+	}
+	return views
+}
+
+func roundVolumeSize(size int) int {
+	if size%mebibyte == 0 {
+		return size
+	}
+	return (size/mebibyte + 1) * mebibyte
+}
+
+// validateFifoSize checks the number of snapshots a volume keeps, of which minFifoSize are reserved for
+// FIFO snapshots
+func validateFifoSize(fifoSize int, minFifoSize int) error {
+	if fifoSize < 1 || fifoSize > maxFifoSize {
+		return newError("xInvalidParameter", "fifoSize must be between 1 and %v", maxFifoSize)
+	}
+	if minFifoSize < 0 || minFifoSize > fifoSize {
+		return newError("xInvalidParameter", "minFifoSize must be between 0 and fifoSize")
+	}
+	return nil
+}
+
+func validateVolumeSize(size int) error {
+	if size < minVolumeSize || size > maxVolumeSize {
+		return newError("xInvalidParameter", "totalSize must be between %v and %v bytes", minVolumeSize, maxVolumeSize)
+	}
+	return nil
+}
+
+func validateQoS(qos QoS) error {
+	switch {
+	case qos.MinIOPS < volumeMinIOPSMin || qos.MinIOPS > volumeMinIOPSMax:
+		return newError("xInvalidParameter", "minIOPS must be between %v and %v", volumeMinIOPSMin, volumeMinIOPSMax)
+	case qos.MaxIOPS < volumeMaxIOPSMin || qos.MaxIOPS > volumeMaxIOPSMax:
+		return newError("xInvalidParameter", "maxIOPS must be between %v and %v", volumeMaxIOPSMin, volumeMaxIOPSMax)
+	case qos.BurstIOPS < volumeBurstIOPSMin || qos.BurstIOPS > volumeBurstIOPSMax:
+		return newError("xInvalidParameter", "burstIOPS must be between %v and %v", volumeBurstIOPSMin, volumeBurstIOPSMax)
+	case qos.MinIOPS > qos.MaxIOPS || qos.MaxIOPS > qos.BurstIOPS:
+		return newError("xInvalidQoS", "QoS must satisfy minIOPS <= maxIOPS <= burstIOPS")
+	}
+	return nil
+}
+
+// mergeQoS applies the non-zero values of the requested QoS settings
+func mergeQoS(qos QoS, requested *QoS) QoS {
+	if requested == nil {
+		return qos
+	}
+	if requested.MinIOPS != 0 {
+		qos.MinIOPS = requested.MinIOPS
+	}
+	if requested.MaxIOPS != 0 {
+		qos.MaxIOPS = requested.MaxIOPS
+	}
+	if requested.BurstIOPS != 0 {
+		qos.BurstIOPS = requested.BurstIOPS
+	}
+	return qos
+}
+
+func validateAccess(access string) error {
+	switch access {
+	case "readWrite", "readOnly", "locked", "replicationTarget":
+		return nil
+	}
+	return newError("xInvalidParameter", "Invalid access %v", access)
+}
+
+func createVolume(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Name        string                 `json:"name"`
+		AccountID   int                    `json:"accountID"`
+		TotalSize   int                    `json:"totalSize"`
+		Enable512e  bool                   `json:"enable512e"`
+		Access      *string                `json:"access"`
+		QoS         *QoS                   `json:"qos"`
+		QoSPolicyID int                    `json:"qosPolicyID"`
+		FifoSize    *int                   `json:"fifoSize"`
+		MinFifoSize *int                   `json:"minFifoSize"`
+		Attributes  map[string]interface{} `json:"attributes"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	if p.Name == "" {
+		return nil, newError("xMissingParameter", "name is required")
+	}
+	// Only recent Element releases accept access on CreateVolume, so the fake models the older API levels
+	// the provider supports, where it has to be set with ModifyVolume
+	if p.Access != nil {
+		return nil, newError("xInvalidParameter", "access is not a parameter of CreateVolume")
+	}
+	if _, err := s.account(p.AccountID); err != nil {
+		return nil, err
+	}
+	if err := validateVolumeSize(p.TotalSize); err != nil {
+		return nil, err
+	}
+	fifoSize, minFifoSize := defaultFifoSize, 0
+	if p.FifoSize != nil {
+		fifoSize = *p.FifoSize
+	}
+	if p.MinFifoSize != nil {
+		minFifoSize = *p.MinFifoSize
+	}
+	if err := validateFifoSize(fifoSize, minFifoSize); err != nil {
+		return nil, err
+	}
+
+	qos := mergeQoS(QoS{MinIOPS: defaultMinIOPS, MaxIOPS: defaultMaxIOPS, BurstIOPS: defaultBurstIOPS, BurstTime: 60}, p.QoS)
+	if err := validateQoS(qos); err != nil {
+		return nil, err
+	}
+
+	var policy *QoSPolicy
+	if p.QoSPolicyID != 0 {
+		if p.QoS != nil {
+			return nil, newError("xInvalidParameter", "qos and qosPolicyID cannot both be set")
+		}
+		var err error
+		if policy, err = s.qosPolicy(p.QoSPolicyID); err != nil {
+			return nil, err
+		}
+		qos = policy.QoS
+	}
+
+	volume := s.newVolume(p.Name, p.AccountID, p.TotalSize, p.Enable512e, "readWrite", qos, p.Attributes)
+	volume.FifoSize = fifoSize
+	volume.MinFifoSize = minFifoSize
+	if policy != nil {
+		volume.QoSPolicyID = &policy.QoSPolicyID
+	}
+
+	return map[string]interface{}{
+		"volumeID": volume.VolumeID,
+		"volume":   s.volumeView(volume),
+	}, nil
+}
+
+func (s *Server) newVolume(name string, accountID int, totalSize int, enable512e bool, access string, qos QoS, attributes map[string]interface{}) *Volume {
+	id := s.newID("volume")
+	if attributes == nil {
+		attributes = map[string]interface{}{}
+	}
+
+	volume := &Volume{
+		VolumeID:        id,
+		Name:            name,
+		AccountID:       accountID,
+		CreateTime:      s.timestamp(),
+		Status:          "active",
+		Access:          access,
+		Enable512e:      enable512e,
+		Iqn:             fmt.Sprintf("iqn.2010-01.com.solidfire:fake.%v.%v", name, id),
+		ScsiEUIDeviceID: fmt.Sprintf("66616b65%08x", id),
+		ScsiNAADeviceID: fmt.Sprintf("6f47acc10000000066616b65%08x", id),
+		QoS:             qos,
+		TotalSize:       roundVolumeSize(totalSize),
+		BlockSize:       4096,
+		VolumePairs:     []interface{}{},
+		SliceCount:      1,
+		FifoSize:        defaultFifoSize,
+		Attributes:      attributes,
+	}
+	s.volumes[id] = volume
+	return volume
+}
+
+func cloneVolume(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		VolumeID     int                    `json:"volumeID"`
+		Name         string                 `json:"name"`
+		NewAccountID int                    `json:"newAccountID"`
+		NewSize      int                    `json:"newSize"`
+		Access       string                 `json:"access"`
+		SnapshotID   int                    `json:"snapshotID"`
+		Enable512e   *bool                  `json:"enable512e"`
+		Attributes   map[string]interface{} `json:"attributes"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	source, err := s.volume(p.VolumeID)
+	if err != nil {
+		return nil, err
+	}
+	if source.Status != "active" {
+		return nil, newError("xVolumeIDDoesNotExist", "Volume %v is deleted", p.VolumeID)
+	}
+	if p.Name == "" {
+		return nil, newError("xMissingParameter", "name is required")
+	}
+	if p.SnapshotID != 0 {
+		snapshot, err := s.snapshot(p.SnapshotID)
+		if err != nil {
+			return nil, err
+		}
+		if snapshot.VolumeID != source.VolumeID {
+			return nil, newError("xSnapshotIDDoesNotExist", "Snapshot %v does not belong to volume %v", p.SnapshotID, p.VolumeID)
+		}
+	}
+
+	accountID := source.AccountID
+	if p.NewAccountID != 0 {
+		if _, err := s.account(p.NewAccountID); err != nil {
+			return nil, err
+		}
+		accountID = p.NewAccountID
+	}
+
+	size := source.TotalSize
+	if p.NewSize != 0 {
+		if err := validateVolumeSize(p.NewSize); err != nil {
+			return nil, err
+		}
+		if roundVolumeSize(p.NewSize) < source.TotalSize {
+			return nil, newError("xInvalidSize", "The size of a clone cannot be smaller than the size of its source")
+		}
+		size = p.NewSize
+	}
+
+	access := source.Access
+	if p.Access != "" {
+		if err := validateAccess(p.Access); err != nil {
+			return nil, err
+		}
+		access = p.Access
+	}
+
+	enable512e := source.Enable512e
+	if p.Enable512e != nil {
+		enable512e = *p.Enable512e
+	}
+
+	volume := s.newVolume(p.Name, accountID, size, enable512e, access, source.QoS, p.Attributes)
+	cloneID := s.newID("clone")
+	handle := s.newAsyncResult("Clone", map[string]interface{}{
+		"cloneID":  cloneID,
+		"volumeID": volume.VolumeID,
+	})
+
+	return map[string]interface{}{
+		"cloneID":     cloneID,
+		"volumeID":    volume.VolumeID,
+		"asyncHandle": handle,
+		"volume":      s.volumeView(volume),
+	}, nil
+}
+
+func listVolumes(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		StartVolumeID int    `json:"startVolumeID"`
+		Limit         int    `json:"limit"`
+		VolumeStatus  string `json:"volumeStatus"`
+		Accounts      []int  `json:"accounts"`
+		VolumeIDs     []int  `json:"volumeIDs"`
+		VolumeName    string `json:"volumeName"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	for _, id := range p.VolumeIDs {
+		if _, err := s.volume(id); err != nil {
+			return nil, err
+		}
+	}
+
+	views := s.volumeViews(func(v *Volume) bool {
+		if v.VolumeID < p.StartVolumeID {
+			return false
+		}
+		if p.VolumeStatus != "" && v.Status != p.VolumeStatus {
+			return false
+		}
+		if p.VolumeName != "" && v.Name != p.VolumeName {
+			return false
+		}
+		if len(p.Accounts) > 0 && !containsInt(p.Accounts, v.AccountID) {
+			return false
+		}
+		if len(p.VolumeIDs) > 0 && !containsInt(p.VolumeIDs, v.VolumeID) {
+			return false
+		}
+		return true
+	})
+	if p.Limit > 0 && len(views) > p.Limit {
+		views = views[:p.Limit]
+	}
+
+	return map[string]interface{}{"volumes": views}, nil
+}
+
+func listActiveVolumes(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		StartVolumeID int `json:"startVolumeID"`
+		Limit         int `json:"limit"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	views := s.volumeViews(func(v *Volume) bool {
+		return v.Status == "active" && v.VolumeID >= p.StartVolumeID
+	})
+	if p.Limit > 0 && len(views) > p.Limit {
+		views = views[:p.Limit]
+	}
+
+	return map[string]interface{}{"volumes": views}, nil
+}
+
+func listDeletedVolumes(s *Server, params json.RawMessage) (interface{}, error) {
+	s.purgeExpiredVolumes()
+
+	return map[string]interface{}{
+		"volumes": s.volumeViews(func(v *Volume) bool { return v.Status == "deleted" }),
+	}, nil
+}
+
+func listVolumesForAccount(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		AccountID int `json:"accountID"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.account(p.AccountID); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"volumes": s.volumeViews(func(v *Volume) bool { return v.AccountID == p.AccountID }),
+	}, nil
+}
+
+func modifyVolume(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		VolumeID               int                     `json:"volumeID"`
+		AccountID              int                     `json:"accountID"`
+		Access                 string                  `json:"access"`
+		QoS                    *QoS                    `json:"qos"`
+		QoSPolicyID            int                     `json:"qosPolicyID"`
+		AssociateWithQoSPolicy *bool                   `json:"associateWithQoSPolicy"`
+		TotalSize              int                     `json:"totalSize"`
+		FifoSize               *int                    `json:"fifoSize"`
+		MinFifoSize            *int                    `json:"minFifoSize"`
+		Attributes             *map[string]interface{} `json:"attributes"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	volume, err := s.volume(p.VolumeID)
+	if err != nil {
+		return nil, err
+	}
+	if volume.Status != "active" {
+		return nil, newError("xVolumeIDDoesNotExist", "Volume %v is deleted", p.VolumeID)
+	}
+
+	if p.AccountID != 0 {
+		if _, err := s.account(p.AccountID); err != nil {
+			return nil, err
+		}
+	}
+	if p.Access != "" {
+		if err := validateAccess(p.Access); err != nil {
+			return nil, err
+		}
+	}
+	qos := mergeQoS(volume.QoS, p.QoS)
+	if err := validateQoS(qos); err != nil {
+		return nil, err
+	}
+
+	// A volume takes its QoS from its policy until it is disassociated from it
+	policyID := volume.QoSPolicyID
+	if p.AssociateWithQoSPolicy != nil && !*p.AssociateWithQoSPolicy {
+		policyID = nil
+	}
+	if p.QoSPolicyID != 0 {
+		if p.QoS != nil {
+			return nil, newError("xInvalidParameter", "qos and qosPolicyID cannot both be set")
+		}
+		policy, err := s.qosPolicy(p.QoSPolicyID)
+		if err != nil {
+			return nil, err
+		}
+		policyID = &policy.QoSPolicyID
+		qos = policy.QoS
+	} else if p.QoS != nil && policyID != nil {
+		return nil, newError("xInvalidParameter", "Volume %v is associated with QoS policy %v", p.VolumeID, *policyID)
+	}
+
+	if p.TotalSize != 0 {
+		if err := validateVolumeSize(p.TotalSize); err != nil {
+			return nil, err
+		}
+		if roundVolumeSize(p.TotalSize) < volume.TotalSize {
+			return nil, newError("xVolumeShrinkProhibited", "Volume %v cannot be shrunk", p.VolumeID)
+		}
+	}
+	fifoSize, minFifoSize := volume.FifoSize, volume.MinFifoSize
+	if p.FifoSize != nil {
+		fifoSize = *p.FifoSize
+	}
+	if p.MinFifoSize != nil {
+		minFifoSize = *p.MinFifoSize
+	}
+	if err := validateFifoSize(fifoSize, minFifoSize); err != nil {
+		return nil, err
+	}
+
+	if p.AccountID != 0 {
+		volume.AccountID = p.AccountID
+	}
+	if p.Access != "" {
+		volume.Access = p.Access
+	}
+	volume.QoS = qos
+	volume.QoSPolicyID = policyID
+	if p.TotalSize != 0 {
+		volume.TotalSize = roundVolumeSize(p.TotalSize)
+	}
+	volume.FifoSize = fifoSize
+	volume.MinFifoSize = minFifoSize
+	if p.Attributes != nil && *p.Attributes != nil {
+		volume.Attributes = *p.Attributes
+	}
+
+	return map[string]interface{}{"volume": s.volumeView(volume)}, nil
+}
+
+func deleteVolume(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		VolumeID int `json:"volumeID"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	volume, err := s.volume(p.VolumeID)
+	if err != nil {
+		return nil, err
+	}
+	if volume.Status == "deleted" {
+		return nil, newError("xVolumeIDDoesNotExist", "Volume %v is already deleted", p.VolumeID)
+	}
+
+	now := s.now()
+	volume.Status = "deleted"
+	volume.DeleteTime = now.UTC().Format(time.RFC3339)
+	volume.PurgeTime = now.Add(deletedVolumeTTL).UTC().Format(time.RFC3339)
+
+	for _, group := range s.accessGroups {
+		group.Volumes = removeInt(group.Volumes, volume.VolumeID)
+	}
+
+	return map[string]interface{}{"volume": s.volumeView(volume)}, nil
+}
+
+func restoreDeletedVolume(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		VolumeID int `json:"volumeID"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	volume, err := s.volume(p.VolumeID)
+	if err != nil {
+		return nil, err
+	}
+	if volume.Status != "deleted" {
+		return nil, newError("xVolumeNotDeleted", "Volume %v is not deleted", p.VolumeID)
+	}
+
+	volume.Status = "active"
+	volume.DeleteTime = ""
+	volume.PurgeTime = ""
+
+	return map[string]interface{}{}, nil
+}
+
+func purgeDeletedVolume(s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		VolumeID int `json:"volumeID"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	volume, err := s.volume(p.VolumeID)
+	if err != nil {
+		return nil, err
+	}
+	if volume.Status != "deleted" {
+		return nil, newError("xVolumeNotDeleted", "Volume %v must be deleted before it can be purged", p.VolumeID)
+	}
+
+	delete(s.volumes, volume.VolumeID)
+	s.deleteVolumeSnapshots(volume.VolumeID)
+	return map[string]interface{}{}, nil
+}
+
+// purgeExpiredVolumes removes deleted volumes whose recovery window has passed, as the cluster does
+func (s *Server) purgeExpiredVolumes() {
+	now := s.now()
+	for id, volume := range s.volumes {
+		if volume.Status != "deleted" {
+			continue
+		}
+		purgeTime, err := time.Parse(time.RFC3339, volume.PurgeTime)
+		if err == nil && now.After(purgeTime) {
+			delete(s.volumes, id)
+			s.deleteVolumeSnapshots(id)
+		}
+	}
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func removeInt(values []int, value int) []int {
+	result := []int{}
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,252 @@
+// Command apigen generates typed Go bindings for the Element API from a machine readable description of its
+// objects and methods. For every method it emits a request struct, a result struct and a pair of element.Client
+// methods that call it with and without a context.
+//
+// It is run with go generate from the element package:
+//
+//	go generate ./solidfire/element
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"strings"
+	"text/template"
+)
+
+// API is the description of the Element API read from api.json
+type API struct {
+	Objects []Object `json:"objects"`
+	Methods []Method `json:"methods"`
+}
+
+// Object is a structure used in method parameters or results
+type Object struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Members     []Member `json:"members"`
+}
+
+// Method is an Element API method
+type Method struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Params      []Member `json:"params"`
+	Result      []Member `json:"result"`
+}
+
+// Member is a parameter of a method or a member of an object or result. Optional members are omitted from
+// requests when they have their zero value. Nullable members are pointers so that they can be omitted
+// independently of their value.
+type Member struct {
+	Name     string `json:"name"`
+	GoName   string `json:"goName"`
+	Type     string `json:"type"`
+	Array    bool   `json:"array"`
+	Optional bool   `json:"optional"`
+	Nullable bool   `json:"nullable"`
+}
+
+var primitiveTypes = map[string]string{
+	"integer":    "int",
+	"float":      "float64",
+	"string":     "string",
+	"boolean":    "bool",
+	"attributes": "interface{}",
+	"json":       "json.RawMessage",
+}
+
+func main() {
+	in := flag.String("in", "api.json", "API description to read")
+	out := flag.String("out", "api_generated.go", "Go file to write")
+	pkg := flag.String("package", "element", "package name of the generated file")
+	flag.Parse()
+
+	data, err := ioutil.ReadFile(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var api API
+	if err := json.Unmarshal(data, &api); err != nil {
+		log.Fatalf("Unable to parse %v: %v", *in, err)
+	}
+
+	src, err := generate(&api, *pkg, *in)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func generate(api *API, pkg string, source string) ([]byte, error) {
+	if err := validate(api); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err := fileTemplate.Execute(&buf, map[string]interface{}{
+		"Package":  pkg,
+		"Source":   source,
+		"API":      api,
+		"UsesJSON": usesType(api, "json"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("Generated code does not compile: %v\n%s", err, buf.Bytes())
+	}
+	return src, nil
+}
+
+// validate checks that every member refers to a known type and that no names are declared twice
+func validate(api *API) error {
+	known := map[string]bool{}
+	for _, o := range api.Objects {
+		if known[o.Name] {
+			return fmt.Errorf("Object %v is declared more than once", o.Name)
+		}
+		known[o.Name] = true
+	}
+
+	check := func(owner string, members []Member) error {
+		for _, m := range members {
+			if _, ok := primitiveTypes[m.Type]; !ok && !known[m.Type] {
+				return fmt.Errorf("%v.%v has unknown type %v", owner, m.Name, m.Type)
+			}
+		}
+		return nil
+	}
+
+	for _, o := range api.Objects {
+		if err := check(o.Name, o.Members); err != nil {
+			return err
+		}
+	}
+
+	methods := map[string]bool{}
+	for _, m := range api.Methods {
+		if methods[m.Name] {
+			return fmt.Errorf("Method %v is declared more than once", m.Name)
+		}
+		methods[m.Name] = true
+		if err := check(m.Name, m.Params); err != nil {
+			return err
+		}
+		if err := check(m.Name, m.Result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// usesType reports whether any member of api has type t
+func usesType(api *API, t string) bool {
+	var lists [][]Member
+	for _, o := range api.Objects {
+		lists = append(lists, o.Members)
+	}
+	for _, m := range api.Methods {
+		lists = append(lists, m.Params, m.Result)
+	}
+	for _, members := range lists {
+		for _, m := range members {
+			if m.Type == t {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func fieldName(m Member) string {
+	if m.GoName != "" {
+		return m.GoName
+	}
+	return strings.ToUpper(m.Name[:1]) + m.Name[1:]
+}
+
+func fieldType(m Member) string {
+	t, ok := primitiveTypes[m.Type]
+	if !ok {
+		t = m.Type
+	}
+	if m.Array {
+		t = "[]" + t
+	}
+	if m.Nullable {
+		t = "*" + t
+	}
+	return t
+}
+
+func fieldTag(m Member) string {
+	name := m.Name
+	if m.Optional {
+		name += ",omitempty"
+	}
+	return fmt.Sprintf("`json:%q structs:%q`", name, name)
+}
+
+var fileTemplate = template.Must(template.New("file").Funcs(template.FuncMap{
+	"fieldName": fieldName,
+	"fieldType": fieldType,
+	"fieldTag":  fieldTag,
+}).Parse(`// Code generated by apigen from {{.Source}}. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+{{- if .UsesJSON}}
+	"encoding/json"
+{{- end}}
+
+	"github.com/fatih/structs"
+)
+{{range .API.Objects}}
+// {{.Description}}
+type {{.Name}} struct {
+{{- range .Members}}
+	{{fieldName .}} {{fieldType .}} {{fieldTag .}}
+{{- end}}
+}
+{{end}}
+{{- range .API.Methods}}
+// {{.Name}}Request holds the parameters of {{.Name}}
+type {{.Name}}Request struct {
+{{- range .Params}}
+	{{fieldName .}} {{fieldType .}} {{fieldTag .}}
+{{- end}}
+}
+
+// {{.Name}}Result holds the result of {{.Name}}
+type {{.Name}}Result struct {
+{{- range .Result}}
+	{{fieldName .}} {{fieldType .}} {{fieldTag .}}
+{{- end}}
+}
+
+// {{.Description}}
+func (c *Client) {{.Name}}(request {{.Name}}Request) ({{.Name}}Result, error) {
+	return c.{{.Name}}Context(c.Context(), request)
+}
+
+// {{.Name}}Context is like {{.Name}} but is canceled with ctx
+func (c *Client) {{.Name}}Context(ctx context.Context, request {{.Name}}Request) ({{.Name}}Result, error) {
+	var result {{.Name}}Result
+	err := c.callMethod(ctx, "{{.Name}}", structs.Map(request), &result)
+	return result, err
+}
+{{end}}`))
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratedCodeIsUpToDate(t *testing.T) {
+	data, err := ioutil.ReadFile("../../api.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var api API
+	if err := json.Unmarshal(data, &api); err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := generate(&api, "element", "api.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ioutil.ReadFile("../../api_generated.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != string(want) {
+		t.Error("api_generated.go is out of date, run go generate ./solidfire/element")
+	}
+}
+
+func TestValidateRejectsUnknownTypes(t *testing.T) {
+	api := &API{
+		Methods: []Method{{
+			Name:   "ListVolumes",
+			Result: []Member{{Name: "volumes", Type: "Volume", Array: true}},
+		}},
+	}
+
+	err := validate(api)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "ListVolumes.volumes has unknown type Volume")
+	}
+}
+
+func TestFieldType(t *testing.T) {
+	assert.Equal(t, "int", fieldType(Member{Type: "integer"}))
+	assert.Equal(t, "[]string", fieldType(Member{Type: "string", Array: true}))
+	assert.Equal(t, "*QoS", fieldType(Member{Type: "QoS", Nullable: true}))
+	assert.Equal(t, "interface{}", fieldType(Member{Type: "attributes"}))
+	assert.Equal(t, "*json.RawMessage", fieldType(Member{Type: "json", Nullable: true}))
+	assert.Equal(t, "`json:\"limit,omitempty\" structs:\"limit,omitempty\"`", fieldTag(Member{Name: "limit", Optional: true}))
+}
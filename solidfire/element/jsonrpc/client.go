@@ -1,11 +1,13 @@
 package jsonrpc
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"log"
 	"net/http"
 	"sync"
+	"time"
 )
 
 // Client represents a client for interaction with a JSON RPC API
@@ -14,6 +16,7 @@ type Client struct {
 	Username      string
 	Password      string
 	HTTPTransport http.RoundTripper
+	RetryPolicy   *RetryPolicy
 
 	initOnce   sync.Once
 	httpClient http.Client
@@ -25,29 +28,72 @@ func (c *Client) init() {
 	}
 }
 
-// Do sends the API Request, parses the response as JSON, and returns the "result" value as raw JSON
+// Do sends the API Request, parses the response as JSON, and returns the "result" value as raw JSON.
+// Idempotent methods that fail with a retryable error are retried according to the client's RetryPolicy.
 func (c *Client) Do(req *Request) (*json.RawMessage, error) {
+	return c.DoContext(context.Background(), req)
+}
+
+// DoContext is like Do but aborts the HTTP request, and any pending retry, when ctx is done
+func (c *Client) DoContext(ctx context.Context, req *Request) (*json.RawMessage, error) {
 	c.initOnce.Do(c.init)
 
+	for attempt := 0; ; attempt++ {
+		result, err := c.do(ctx, req)
+		if err != nil && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err == nil || c.RetryPolicy == nil || !c.RetryPolicy.IsRetryable(err) {
+			return result, err
+		}
+
+		if !IsIdempotentMethod(req.Method) {
+			log.Printf("Not retrying %s after transient error because the method is not idempotent: %s", req.Method, err)
+			return nil, err
+		}
+
+		if attempt >= c.RetryPolicy.MaxRetries {
+			log.Printf("Giving up on %s after %d attempts: %s", req.Method, attempt+1, err)
+			return nil, err
+		}
+
+		delay := c.RetryPolicy.Backoff(attempt + 1)
+		log.Printf("Retrying %s in %s after transient error: %s", req.Method, delay, err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (c *Client) do(ctx context.Context, req *Request) (*json.RawMessage, error) {
 	httpReq, err := req.BuildHTTPReq(c.Host, c.Username, c.Password)
 	if err != nil {
 		return nil, err
 	}
+	httpReq = httpReq.WithContext(ctx)
 
 	httpRes, err := c.httpClient.Do(httpReq)
 	if err != nil {
 		log.Print("HTTP req failed")
 		return nil, err
 	}
+	defer httpRes.Body.Close()
 
 	if httpRes.StatusCode == 401 {
-		return nil, errors.New("Unauthenticated")
+		return nil, &StatusError{StatusCode: httpRes.StatusCode, Status: httpRes.Status}
 	}
 
-	defer httpRes.Body.Close()
 	var res Response
 
 	if err := json.NewDecoder(httpRes.Body).Decode(&res); err != nil {
+		if httpRes.StatusCode >= 500 {
+			return nil, &StatusError{StatusCode: httpRes.StatusCode, Status: httpRes.Status}
+		}
 		log.Print("HTTP decoder failed")
 		return nil, err
 	}
@@ -56,6 +102,10 @@ func (c *Client) Do(req *Request) (*json.RawMessage, error) {
 		return nil, res.Error
 	}
 
+	if httpRes.StatusCode >= 500 {
+		return nil, &StatusError{StatusCode: httpRes.StatusCode, Status: httpRes.Status}
+	}
+
 	if res.Result == nil {
 		return nil, errors.New("No result returned in JSON RPC response.")
 	}
@@ -1,9 +1,11 @@
 package jsonrpc
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/h2non/gock.v1"
@@ -99,3 +101,171 @@ func TestMalformedResponse(t *testing.T) {
 		t.Fatal("Expected error to be returned")
 	}
 }
+
+func TestRetryIdempotentMethod(t *testing.T) {
+	defer gock.Off()
+
+	fakeHost := "http://fakehost"
+
+	gock.New(fakeHost).
+		Post("/path").
+		Reply(http.StatusOK).
+		JSON(map[string]interface{}{
+			"error": map[string]interface{}{
+				"code":    500,
+				"message": "not the cluster master",
+				"name":    "xNotPrimary",
+			},
+		})
+	gock.New(fakeHost).
+		Post("/path").
+		Reply(http.StatusServiceUnavailable)
+	gock.New(fakeHost).
+		Post("/path").
+		Reply(http.StatusOK).
+		JSON(map[string]interface{}{
+			"result": map[string]interface{}{
+				"volumes": []interface{}{},
+			},
+		})
+
+	client := &Client{
+		Host: fakeHost,
+		RetryPolicy: &RetryPolicy{
+			MaxRetries: 3,
+			MinBackoff: time.Millisecond,
+			MaxBackoff: 5 * time.Millisecond,
+			Jitter:     0.5,
+		},
+	}
+
+	result, err := client.Do(&Request{
+		BaseURL: "/path",
+		Method:  "ListVolumes",
+		Params:  map[string]interface{}{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotNil(t, result)
+	assert.True(t, gock.IsDone())
+}
+
+func TestNoRetryForMutatingMethod(t *testing.T) {
+	defer gock.Off()
+
+	fakeHost := "http://fakehost"
+
+	gock.New(fakeHost).
+		Post("/path").
+		Reply(http.StatusOK).
+		JSON(map[string]interface{}{
+			"error": map[string]interface{}{
+				"code":    500,
+				"message": "not the cluster master",
+				"name":    "xNotPrimary",
+			},
+		})
+	gock.New(fakeHost).
+		Post("/path").
+		Reply(http.StatusOK).
+		JSON(map[string]interface{}{
+			"result": map[string]interface{}{
+				"volumeID": 1,
+			},
+		})
+
+	client := &Client{
+		Host: fakeHost,
+		RetryPolicy: &RetryPolicy{
+			MaxRetries: 3,
+			MinBackoff: time.Millisecond,
+		},
+	}
+
+	_, err := client.Do(&Request{
+		BaseURL: "/path",
+		Method:  "CreateVolume",
+		Params:  map[string]interface{}{},
+	})
+	if err == nil {
+		t.Fatal("Expected error to be returned")
+	}
+
+	assert.False(t, gock.IsDone())
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	defer gock.Off()
+
+	fakeHost := "http://fakehost"
+
+	gock.New(fakeHost).
+		Post("/path").
+		Times(3).
+		Reply(http.StatusBadGateway)
+
+	client := &Client{
+		Host: fakeHost,
+		RetryPolicy: &RetryPolicy{
+			MaxRetries: 2,
+			MinBackoff: time.Millisecond,
+		},
+	}
+
+	_, err := client.Do(&Request{
+		BaseURL: "/path",
+		Method:  "GetAccountByID",
+		Params:  map[string]interface{}{},
+	})
+	if err == nil {
+		t.Fatal("Expected error to be returned")
+	}
+
+	assert.IsType(t, &StatusError{}, err)
+	assert.True(t, gock.IsDone())
+}
+
+func TestBackoff(t *testing.T) {
+	policy := &RetryPolicy{
+		MinBackoff: 100 * time.Millisecond,
+		MaxBackoff: time.Second,
+	}
+
+	assert.Equal(t, 100*time.Millisecond, policy.Backoff(1))
+	assert.Equal(t, 200*time.Millisecond, policy.Backoff(2))
+	assert.Equal(t, 400*time.Millisecond, policy.Backoff(3))
+	assert.Equal(t, time.Second, policy.Backoff(5))
+	assert.Equal(t, time.Second, policy.Backoff(50))
+}
+
+func TestCanceledContext(t *testing.T) {
+	defer gock.Off()
+
+	fakeHost := "http://fakehost"
+
+	gock.New(fakeHost).
+		Post("/path").
+		Persist().
+		Reply(http.StatusServiceUnavailable)
+
+	client := &Client{
+		Host: fakeHost,
+		RetryPolicy: &RetryPolicy{
+			MaxRetries: 10,
+			MinBackoff: time.Hour,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := client.DoContext(ctx, &Request{
+		BaseURL: "/path",
+		Method:  "ListVolumes",
+		Params:  map[string]interface{}{},
+	})
+
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
@@ -20,3 +20,13 @@ type ResponseError struct {
 func (e *ResponseError) Error() string {
 	return fmt.Sprintf("Request returned an error. %+v", *e)
 }
+
+// StatusError is returned when the API responds with an unexpected HTTP status and no JSON RPC error
+type StatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("Request failed with HTTP status %s", e.Status)
+}
@@ -0,0 +1,110 @@
+package jsonrpc
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// DefaultRetryableErrorNames are the Element API error names that indicate a transient
+// condition on the cluster, such as a master service failover, rather than a problem with the request
+var DefaultRetryableErrorNames = []string{
+	"xDBConnectionLost",
+	"xDBOperationTimeout",
+	"xDBNoServerResponse",
+	"xNotPrimary",
+	"xNotReadyForIO",
+	"xSliceNotRegistered",
+	"xTimeout",
+}
+
+// RetryPolicy configures how failed requests are retried. Only idempotent methods are ever retried,
+// so a mutating call such as CreateVolume is sent at most once regardless of the policy.
+type RetryPolicy struct {
+	// MaxRetries is the number of additional attempts made after the first one fails
+	MaxRetries int
+	// MinBackoff is the delay before the first retry
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between any two attempts
+	MaxBackoff time.Duration
+	// Multiplier is applied to the delay after every attempt. Defaults to 2.
+	Multiplier float64
+	// Jitter is the fraction (0-1) of each delay that is randomized to spread out retries from concurrent requests
+	Jitter float64
+	// RetryableErrorNames are the Element error names to retry. Defaults to DefaultRetryableErrorNames.
+	RetryableErrorNames []string
+}
+
+// IsIdempotentMethod reports whether an Element API method only reads state and is therefore safe to repeat
+func IsIdempotentMethod(method string) bool {
+	return strings.HasPrefix(method, "List") || strings.HasPrefix(method, "Get")
+}
+
+// IsRetryable reports whether err is a transient failure the policy allows to be retried
+func (p *RetryPolicy) IsRetryable(err error) bool {
+	switch e := err.(type) {
+	case *ResponseError:
+		names := p.RetryableErrorNames
+		if names == nil {
+			names = DefaultRetryableErrorNames
+		}
+		for _, name := range names {
+			if e.Name == name {
+				return true
+			}
+		}
+		return false
+	case *StatusError:
+		return e.StatusCode >= 500
+	}
+	return IsNetworkError(err)
+}
+
+// IsNetworkError reports whether err is a connection-level failure, such as a refused or reset connection
+// or a timeout. Certificate verification failures are not network errors and will not succeed on retry.
+func IsNetworkError(err error) bool {
+	var opErr *net.OpError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &opErr):
+		return true
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, syscall.ECONNRESET):
+		return true
+	case errors.As(err, &netErr):
+		return netErr.Timeout()
+	}
+	return false
+}
+
+// Backoff returns the delay to wait before the given retry attempt, starting at 1
+func (p *RetryPolicy) Backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	delay := float64(p.MinBackoff)
+	for i := 1; i < attempt; i++ {
+		delay *= multiplier
+		if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		jitter := p.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		delay -= delay * jitter * rand.Float64()
+	}
+
+	return time.Duration(delay)
+}
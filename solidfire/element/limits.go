@@ -0,0 +1,54 @@
+package element
+
+import (
+	"context"
+	"fmt"
+)
+
+// Limits returns the limits of the cluster. They do not change while the provider runs, so GetLimits is only
+// called once and the result is cached on the client.
+func (c *Client) Limits(ctx context.Context) (GetLimitsResult, error) {
+	c.limitsMutex.Lock()
+	defer c.limitsMutex.Unlock()
+
+	if c.limits == nil {
+		result, err := c.GetLimitsContext(ctx, GetLimitsRequest{})
+		if err != nil {
+			return GetLimitsResult{}, err
+		}
+		c.limits = &result
+	}
+	return *c.limits, nil
+}
+
+// ValidateQoS checks qos against the volume QoS limits of the cluster and the ordering the cluster requires,
+// minIOPS <= maxIOPS <= burstIOPS. Zero values are left to the cluster default and are not checked. The
+// returned error wraps ErrInvalidParameter.
+func (l GetLimitsResult) ValidateQoS(qos QoS) error {
+	checks := []struct {
+		name     string
+		value    int
+		min, max int
+	}{
+		{"minIOPS", qos.MinIOPS, l.VolumeMinIOPSMin, l.VolumeMinIOPSMax},
+		{"maxIOPS", qos.MaxIOPS, l.VolumeMaxIOPSMin, l.VolumeMaxIOPSMax},
+		{"burstIOPS", qos.BurstIOPS, l.VolumeBurstIOPSMin, l.VolumeBurstIOPSMax},
+	}
+	for _, check := range checks {
+		if check.value != 0 && (check.value < check.min || check.value > check.max) {
+			return fmt.Errorf("%v must be between %v and %v on this cluster, got %v: %w",
+				check.name, check.min, check.max, check.value, ErrInvalidParameter)
+		}
+	}
+
+	if qos.MinIOPS != 0 && qos.MaxIOPS != 0 && qos.MinIOPS > qos.MaxIOPS {
+		return fmt.Errorf("minIOPS (%v) must not be greater than maxIOPS (%v): %w", qos.MinIOPS, qos.MaxIOPS, ErrInvalidParameter)
+	}
+	if qos.MaxIOPS != 0 && qos.BurstIOPS != 0 && qos.MaxIOPS > qos.BurstIOPS {
+		return fmt.Errorf("maxIOPS (%v) must not be greater than burstIOPS (%v): %w", qos.MaxIOPS, qos.BurstIOPS, ErrInvalidParameter)
+	}
+	if qos.MinIOPS != 0 && qos.BurstIOPS != 0 && qos.MinIOPS > qos.BurstIOPS {
+		return fmt.Errorf("minIOPS (%v) must not be greater than burstIOPS (%v): %w", qos.MinIOPS, qos.BurstIOPS, ErrInvalidParameter)
+	}
+	return nil
+}
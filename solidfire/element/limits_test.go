@@ -0,0 +1,82 @@
+package element
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestLimitsCached(t *testing.T) {
+	defer gock.Off()
+
+	fakeHost := "http://fakehost"
+
+	// Only one reply is registered, so a second GetLimits request would fail
+	gock.New(fakeHost).
+		Post("/json-rpc/1.0").
+		Reply(http.StatusOK).
+		JSON(map[string]interface{}{
+			"result": map[string]interface{}{
+				"volumeMinIOPSMin":   50,
+				"volumeMinIOPSMax":   15000,
+				"volumeMaxIOPSMin":   100,
+				"volumeMaxIOPSMax":   200000,
+				"volumeBurstIOPSMin": 100,
+				"volumeBurstIOPSMax": 200000,
+			},
+		})
+
+	client := &Client{Host: fakeHost}
+
+	limits, err := client.Limits(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 15000, limits.VolumeMinIOPSMax)
+
+	limits, err = client.Limits(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 200000, limits.VolumeBurstIOPSMax)
+	assert.True(t, gock.IsDone())
+}
+
+func TestValidateQoS(t *testing.T) {
+	limits := GetLimitsResult{
+		VolumeMinIOPSMin:   50,
+		VolumeMinIOPSMax:   15000,
+		VolumeMaxIOPSMin:   100,
+		VolumeMaxIOPSMax:   200000,
+		VolumeBurstIOPSMin: 100,
+		VolumeBurstIOPSMax: 200000,
+	}
+
+	valid := []QoS{
+		{},
+		{MinIOPS: 500, MaxIOPS: 10000, BurstIOPS: 10000},
+		{MaxIOPS: 500},
+		{MinIOPS: 15000, BurstIOPS: 200000},
+	}
+	for _, qos := range valid {
+		assert.NoError(t, limits.ValidateQoS(qos), "%+v", qos)
+	}
+
+	invalid := []QoS{
+		{MinIOPS: 20000, MaxIOPS: 500},
+		{MinIOPS: 1000, MaxIOPS: 500},
+		{MaxIOPS: 5000, BurstIOPS: 4000},
+		{MinIOPS: 3000, BurstIOPS: 2000},
+		{MinIOPS: 10},
+		{MaxIOPS: 300000},
+		{BurstIOPS: 300000},
+	}
+	for _, qos := range invalid {
+		err := limits.ValidateQoS(qos)
+		assert.True(t, errors.Is(err, ErrInvalidParameter), "%+v: %v", qos, err)
+	}
+}
@@ -0,0 +1,64 @@
+// Package recorder records Element API interactions made through an http.RoundTripper into cassette files and
+// replays them later without a cluster. Credentials are never recorded and sensitive fields such as CHAP secrets
+// are scrubbed before an interaction is stored.
+package recorder
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Cassette is a sequence of recorded interactions
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a JSON-RPC request and the response the cluster returned for it
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a scrubbed JSON-RPC request. The host and headers are not recorded.
+type RecordedRequest struct {
+	Path   string          `json:"path"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// RecordedResponse is a scrubbed response. Body holds JSON responses and Text anything else, such as the
+// HTML page returned for failed authentication.
+type RecordedResponse struct {
+	StatusCode int             `json:"statusCode"`
+	Body       json.RawMessage `json:"body,omitempty"`
+	Text       string          `json:"text,omitempty"`
+}
+
+// Load reads a cassette from path
+func Load(path string) (*Cassette, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, err
+	}
+	return &cassette, nil
+}
+
+// Save writes the cassette to path, creating its directory if needed
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0644)
+}
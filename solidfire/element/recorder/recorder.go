@@ -0,0 +1,265 @@
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+// jsonrpcRequest is the part of a JSON-RPC request body that identifies the call
+type jsonrpcRequest struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// Recorder is an http.RoundTripper that passes requests to Transport and records each request and
+// response into a cassette
+type Recorder struct {
+	// Transport makes the requests. http.DefaultTransport is used if it is nil.
+	Transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder returns a Recorder that sends requests with transport
+func NewRecorder(transport http.RoundTripper) *Recorder {
+	return &Recorder{Transport: transport}
+}
+
+// RoundTrip makes the request and records it. Requests that fail without a response are not recorded.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	return r.record(req, r.Transport)
+}
+
+// Wrap returns an http.RoundTripper that makes requests with transport and records them into the same
+// cassette as r. It allows several clients with their own transports to share a recording.
+func (r *Recorder) Wrap(transport http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return r.record(req, transport)
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func (r *Recorder) record(req *http.Request, transport http.RoundTripper) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	res, err := transport.RoundTrip(withBody(req, reqBody))
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(resBody))
+
+	var call jsonrpcRequest
+	if err := json.Unmarshal(reqBody, &call); err != nil {
+		return nil, fmt.Errorf("Unable to record request that is not JSON-RPC: %v", err)
+	}
+
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Path:   req.URL.Path,
+			Method: call.Method,
+		},
+		Response: RecordedResponse{
+			StatusCode: res.StatusCode,
+		},
+	}
+	if len(call.Params) > 0 {
+		interaction.Request.Params, _ = scrubJSON(call.Params)
+	}
+	if body, ok := scrubJSON(resBody); ok {
+		interaction.Response.Body = body
+	} else {
+		interaction.Response.Text = string(resBody)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	return res, nil
+}
+
+// Cassette returns a copy of the interactions recorded so far
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	interactions := make([]Interaction, len(r.cassette.Interactions))
+	copy(interactions, r.cassette.Interactions)
+	return &Cassette{Interactions: interactions}
+}
+
+// Save writes the interactions recorded so far to path
+func (r *Recorder) Save(path string) error {
+	return r.Cassette().Save(path)
+}
+
+// Replayer is an http.RoundTripper that answers requests from a cassette without making network calls.
+// A request is answered by the first unused interaction with the same JSON-RPC method and params, so
+// repeated identical calls are answered in the order they were recorded. Once all matching interactions
+// have been used the last one is replayed again.
+type Replayer struct {
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// NewReplayer returns a Replayer that answers requests from cassette
+func NewReplayer(cassette *Cassette) *Replayer {
+	return &Replayer{
+		cassette: cassette,
+		used:     make([]bool, len(cassette.Interactions)),
+	}
+}
+
+// LoadReplayer returns a Replayer that answers requests from the cassette stored at path
+func LoadReplayer(path string) (*Replayer, error) {
+	cassette, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewReplayer(cassette), nil
+}
+
+// RoundTrip answers the request from the cassette, or fails if no recorded interaction matches it
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var call jsonrpcRequest
+	if err := json.Unmarshal(reqBody, &call); err != nil {
+		return nil, fmt.Errorf("Unable to replay request that is not JSON-RPC: %v", err)
+	}
+
+	var params interface{}
+	if len(call.Params) > 0 {
+		if err := json.Unmarshal(call.Params, &params); err != nil {
+			return nil, err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	match := -1
+	for i, interaction := range r.cassette.Interactions {
+		if !r.matches(interaction.Request, call.Method, params) {
+			continue
+		}
+		match = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("No recorded interaction matches %v with params %s", call.Method, call.Params)
+	}
+	r.used[match] = true
+
+	recorded := r.cassette.Interactions[match].Response
+	body := []byte(recorded.Text)
+	if len(recorded.Body) > 0 {
+		body = recorded.Body
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (r *Replayer) matches(recorded RecordedRequest, method string, params interface{}) bool {
+	if recorded.Method != method {
+		return false
+	}
+
+	var recordedParams interface{}
+	if len(recorded.Params) > 0 {
+		if err := json.Unmarshal(recorded.Params, &recordedParams); err != nil {
+			return false
+		}
+	}
+	return matchValue(recordedParams, params)
+}
+
+// matchValue compares a recorded value with an actual one. A scrubbed recorded value matches anything.
+func matchValue(recorded, actual interface{}) bool {
+	if recorded == Scrubbed {
+		return true
+	}
+
+	switch recorded := recorded.(type) {
+	case map[string]interface{}:
+		actual, ok := actual.(map[string]interface{})
+		if !ok || len(recorded) != len(actual) {
+			return false
+		}
+		for key, value := range recorded {
+			if !matchValue(value, actual[key]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		actual, ok := actual.([]interface{})
+		if !ok || len(recorded) != len(actual) {
+			return false
+		}
+		for i := range recorded {
+			if !matchValue(recorded[i], actual[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(recorded, actual)
+}
+
+// readRequestBody reads and closes the body of req
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	return ioutil.ReadAll(req.Body)
+}
+
+// withBody returns a copy of req that sends body
+func withBody(req *http.Request, body []byte) *http.Request {
+	out := req.Clone(req.Context())
+	out.Body = ioutil.NopCloser(bytes.NewReader(body))
+	out.ContentLength = int64(len(body))
+	return out
+}
@@ -0,0 +1,174 @@
+package recorder_test
+
+import (
+	"crypto/tls"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element"
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element/fake"
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element/recorder"
+	"github.com/stretchr/testify/assert"
+)
+
+func newClient(host string, transport http.RoundTripper) *element.Client {
+	client := &element.Client{
+		Host:          host,
+		Username:      "admin",
+		Password:      "admin",
+		HTTPTransport: transport,
+	}
+	client.SetAPIVersion("8.0")
+	return client
+}
+
+func recordAccount(t *testing.T) *recorder.Cassette {
+	server := fake.NewServer()
+	defer server.Close()
+
+	rec := recorder.NewRecorder(&http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	})
+	client := newClient(server.URL(), rec)
+
+	account, err := client.AddAccount(element.AddAccountRequest{
+		Username:        "tenant",
+		InitiatorSecret: "initiator-secret",
+		TargetSecret:    "target-secret1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetAccountByID(element.GetAccountByIDRequest{AccountID: account.AccountID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err = client.RemoveAccount(element.RemoveAccountRequest{AccountID: account.AccountID})
+	assert.NoError(t, err)
+	_, err = client.GetAccountByID(element.GetAccountByIDRequest{AccountID: account.AccountID})
+	assert.Error(t, err)
+
+	return rec.Cassette()
+}
+
+func TestRecordScrubsSecrets(t *testing.T) {
+	cassette := recordAccount(t)
+	dir, err := ioutil.TempDir("", "cassette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "account.json")
+	if err := cassette.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := recorder.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, loaded.Interactions, 5)
+
+	for _, interaction := range loaded.Interactions {
+		for _, data := range []string{string(interaction.Request.Params), string(interaction.Response.Body)} {
+			assert.NotContains(t, data, "initiator-secret")
+			assert.NotContains(t, data, "target-secret1")
+			assert.NotContains(t, data, "admin")
+		}
+	}
+
+	assert.Equal(t, "AddAccount", loaded.Interactions[0].Request.Method)
+	assert.Equal(t, "/json-rpc/8.0", loaded.Interactions[0].Request.Path)
+	assert.True(t, strings.Contains(string(loaded.Interactions[0].Request.Params), recorder.Scrubbed))
+}
+
+func TestReplay(t *testing.T) {
+	replayer := recorder.NewReplayer(recordAccount(t))
+	client := newClient("https://cluster.invalid", replayer)
+
+	account, err := client.AddAccount(element.AddAccountRequest{
+		Username:        "tenant",
+		InitiatorSecret: "a-different-secret",
+		TargetSecret:    "target-secret1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, recorder.Scrubbed, account.Account.InitiatorSecret)
+
+	getAccount := element.GetAccountByIDRequest{AccountID: account.AccountID}
+	for i := 0; i < 2; i++ {
+		res, err := client.GetAccountByID(getAccount)
+		assert.NoError(t, err)
+		assert.Equal(t, "tenant", res.Account.Username)
+	}
+
+	_, err = client.RemoveAccount(element.RemoveAccountRequest{AccountID: account.AccountID})
+	assert.NoError(t, err)
+
+	_, err = client.GetAccountByID(getAccount)
+	assert.True(t, errors.Is(err, element.ErrNotFound), "expected ErrNotFound, got %v", err)
+
+	_, err = client.AddAccount(element.AddAccountRequest{Username: "other"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "No recorded interaction matches AddAccount")
+	}
+}
+
+func TestReplayInOrder(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	rec := recorder.NewRecorder(&http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	})
+	client := newClient(server.URL(), rec)
+
+	account, err := client.AddAccount(element.AddAccountRequest{Username: "tenant"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	getAccount := element.GetAccountByIDRequest{AccountID: account.AccountID}
+	if _, err := client.GetAccountByID(getAccount); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ModifyAccount(element.ModifyAccountRequest{AccountID: account.AccountID, Status: "locked"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetAccountByID(getAccount); err != nil {
+		t.Fatal(err)
+	}
+
+	client = newClient("https://cluster.invalid", recorder.NewReplayer(rec.Cassette()))
+	for _, status := range []string{"active", "locked", "locked"} {
+		res, err := client.GetAccountByID(getAccount)
+		assert.NoError(t, err)
+		assert.Equal(t, status, res.Account.Status)
+	}
+}
+
+func TestWrapSharesCassette(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	rec := recorder.NewRecorder(nil)
+	for i := 0; i < 2; i++ {
+		client := newClient(server.URL(), rec.Wrap(&http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}))
+		if _, err := client.ListVolumes(element.ListVolumesRequest{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assert.Len(t, rec.Cassette().Interactions, 2)
+}
@@ -0,0 +1,45 @@
+package recorder
+
+import (
+	"encoding/json"
+
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element"
+)
+
+// Scrubbed replaces the values of sensitive fields, as defined by element.IsSensitiveField, in recorded
+// interactions. When replaying, a recorded Scrubbed value matches any value.
+const Scrubbed = "[SCRUBBED]"
+
+// scrubJSON returns data with the values of scrubbed fields replaced, or data unchanged if it is not JSON
+func scrubJSON(data []byte) (json.RawMessage, bool) {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, false
+	}
+
+	scrubbed, err := json.Marshal(scrubValue(v))
+	if err != nil {
+		return nil, false
+	}
+	return scrubbed, true
+}
+
+func scrubValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if element.IsSensitiveField(key) {
+				if value != nil {
+					v[key] = Scrubbed
+				}
+				continue
+			}
+			v[key] = scrubValue(value)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = scrubValue(v[i])
+		}
+	}
+	return v
+}
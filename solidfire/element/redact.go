@@ -0,0 +1,107 @@
+package element
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+)
+
+// Redacted replaces the values of sensitive fields in log output
+const Redacted = "[REDACTED]"
+
+// sensitiveFields are the Element API fields whose values are masked in log output, in addition to any field
+// whose name ends in "secret" or "password". They cover CHAP credentials, cluster admin and LDAP passwords and
+// the S3 keys passed in the script parameters of bulk volume reads and writes.
+var sensitiveFields = []string{
+	"accessKeyID",
+	"secretAccessKey",
+	"authorization",
+}
+
+// UnredactedFields lists sensitive fields that are logged in clear text anyway, or "*" for all of them. It is
+// meant for local debugging and defaults to the comma separated list in the SOLIDFIRE_LOG_UNREDACTED
+// environment variable. Never attach logs written with it to a support ticket.
+var UnredactedFields = parseFieldList(os.Getenv("SOLIDFIRE_LOG_UNREDACTED"))
+
+func parseFieldList(list string) []string {
+	var fields []string
+	for _, f := range strings.Split(list, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// IsSensitiveField reports whether the value of the Element API field named key must not be disclosed.
+// Keys are compared case-insensitively.
+func IsSensitiveField(key string) bool {
+	lower := strings.ToLower(key)
+	if strings.HasSuffix(lower, "secret") || strings.HasSuffix(lower, "password") {
+		return true
+	}
+	for _, f := range sensitiveFields {
+		if strings.EqualFold(f, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func isUnredacted(key string) bool {
+	for _, f := range UnredactedFields {
+		if f == "*" || strings.EqualFold(f, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact returns a copy of v that is safe to log, with the values of sensitive fields replaced by Redacted.
+// v may be a params map, a request or result struct or raw JSON. Values that cannot be converted are replaced
+// entirely.
+func Redact(v interface{}) interface{} {
+	var generic interface{}
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case *json.RawMessage:
+		if v == nil {
+			return nil
+		}
+		if err := json.Unmarshal(*v, &generic); err != nil {
+			return Redacted
+		}
+	case json.RawMessage:
+		if err := json.Unmarshal(v, &generic); err != nil {
+			return Redacted
+		}
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return Redacted
+		}
+		if err := json.Unmarshal(data, &generic); err != nil {
+			return Redacted
+		}
+	}
+	return redactValue(generic)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if value != nil && IsSensitiveField(key) && !isUnredacted(key) {
+				v[key] = Redacted
+				continue
+			}
+			v[key] = redactValue(value)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+	}
+	return v
+}
@@ -0,0 +1,101 @@
+package element
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestRedactParams(t *testing.T) {
+	params := map[string]interface{}{
+		"username":        "tenant",
+		"initiatorSecret": "initiator-secret",
+		"targetSecret":    "target-secret1",
+		"attributes":      map[string]interface{}{"owner": "ops"},
+	}
+
+	redacted := Redact(params).(map[string]interface{})
+	assert.Equal(t, "tenant", redacted["username"])
+	assert.Equal(t, Redacted, redacted["initiatorSecret"])
+	assert.Equal(t, Redacted, redacted["targetSecret"])
+	assert.Equal(t, map[string]interface{}{"owner": "ops"}, redacted["attributes"])
+
+	assert.Equal(t, "initiator-secret", params["initiatorSecret"], "the original params must not be modified")
+}
+
+func TestRedactRequest(t *testing.T) {
+	redacted := Redact(AddAccountRequest{Username: "tenant", TargetSecret: "target-secret1"}).(map[string]interface{})
+	assert.Equal(t, "tenant", redacted["username"])
+	assert.Equal(t, Redacted, redacted["targetSecret"])
+	assert.NotContains(t, redacted, "initiatorSecret")
+}
+
+func TestRedactBulkVolumeParams(t *testing.T) {
+	raw := json.RawMessage(`{
+		"volumeID": 4,
+		"format": "native",
+		"script": "bv_internal.py",
+		"scriptParameters": {
+			"range": {"lba": 0, "blocks": 244224},
+			"write": {
+				"endpoint": "s3",
+				"s3": {"accessKeyID": "AKIAEXAMPLE", "secretAccessKey": "wJalrXUtnFEMI", "bucket": "backups"}
+			}
+		}
+	}`)
+
+	redacted := Redact(raw).(map[string]interface{})
+	s3 := redacted["scriptParameters"].(map[string]interface{})["write"].(map[string]interface{})["s3"].(map[string]interface{})
+	assert.Equal(t, Redacted, s3["accessKeyID"])
+	assert.Equal(t, Redacted, s3["secretAccessKey"])
+	assert.Equal(t, "backups", s3["bucket"])
+}
+
+func TestRedactAllowList(t *testing.T) {
+	defer func(fields []string) { UnredactedFields = fields }(UnredactedFields)
+
+	params := map[string]interface{}{"initiatorSecret": "initiator-secret", "targetSecret": "target-secret1"}
+
+	UnredactedFields = []string{"InitiatorSecret"}
+	redacted := Redact(params).(map[string]interface{})
+	assert.Equal(t, "initiator-secret", redacted["initiatorSecret"])
+	assert.Equal(t, Redacted, redacted["targetSecret"])
+
+	UnredactedFields = parseFieldList("*")
+	redacted = Redact(params).(map[string]interface{})
+	assert.Equal(t, "target-secret1", redacted["targetSecret"])
+}
+
+func TestCallAPIMethodRedactsDebugLog(t *testing.T) {
+	defer gock.Off()
+
+	var buf bytes.Buffer
+	logrus.SetOutput(&buf)
+	logrus.SetLevel(logrus.DebugLevel)
+	defer func() {
+		logrus.SetOutput(os.Stderr)
+		logrus.SetLevel(logrus.InfoLevel)
+	}()
+
+	fakeHost := "http://fakehost"
+	gock.New(fakeHost).
+		Post("/json-rpc/1.0").
+		Reply(http.StatusOK).
+		JSON(map[string]interface{}{
+			"result": map[string]interface{}{"account": map[string]interface{}{"accountID": 1}},
+		})
+
+	client := &Client{Host: fakeHost}
+	_, err := client.ModifyAccount(ModifyAccountRequest{AccountID: 1, InitiatorSecret: "initiator-secret"})
+	assert.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "Calling API")
+	assert.Contains(t, buf.String(), Redacted)
+	assert.NotContains(t, buf.String(), "initiator-secret")
+}
@@ -0,0 +1,176 @@
+package element
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MinimumAPIVersion is the oldest Element API version the client supports
+	MinimumAPIVersion = "8.0"
+	// MaximumAPIVersion is the newest Element API version the client has been tested against
+	MaximumAPIVersion = "12.3"
+
+	// discoveryAPIVersion is understood by every cluster and is used to ask which versions it supports
+	discoveryAPIVersion = "1.0"
+)
+
+// Feature describes Element functionality that is only available from a given API version
+type Feature struct {
+	Name          string
+	MinAPIVersion string
+}
+
+var (
+	FeatureStorageContainers = Feature{Name: "Storage containers", MinAPIVersion: "9.0"}
+	FeatureQoSPolicies       = Feature{Name: "QoS policies", MinAPIVersion: "10.0"}
+	FeatureSnapMirror        = Feature{Name: "SnapMirror replication", MinAPIVersion: "10.1"}
+	FeatureFifoSnapshots     = Feature{Name: "FIFO snapshots", MinAPIVersion: "12.0"}
+)
+
+type GetAPIResult struct {
+	CurrentVersion    json.Number   `json:"currentVersion"`
+	SupportedVersions []json.Number `json:"supportedVersions"`
+}
+
+type GetClusterVersionInfoResult struct {
+	ClusterAPIVersion string `json:"clusterAPIVersion"`
+	ClusterVersion    string `json:"clusterVersion"`
+}
+
+// NegotiateAPIVersion discovers the API versions supported by the cluster and configures the client to use
+// pinned, failing if the cluster does not support it. If pinned is empty, the highest version supported by
+// both the cluster and the client is used.
+func (c *Client) NegotiateAPIVersion(ctx context.Context, pinned string) (string, error) {
+	supported, err := c.clusterAPIVersions(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if pinned != "" {
+		for _, v := range supported {
+			if compareAPIVersions(v, pinned) == 0 {
+				if compareAPIVersions(pinned, MaximumAPIVersion) > 0 {
+					log.Printf("Element API version %v is newer than the latest version the provider supports (%v)", pinned, MaximumAPIVersion)
+				}
+				c.SetAPIVersion(v)
+				return v, nil
+			}
+		}
+		return "", fmt.Errorf("Element API version %v is not supported by the cluster, which supports versions %v", pinned, strings.Join(supported, ", "))
+	}
+
+	negotiated := ""
+	for _, v := range supported {
+		if compareAPIVersions(v, MinimumAPIVersion) < 0 || compareAPIVersions(v, MaximumAPIVersion) > 0 {
+			continue
+		}
+		if negotiated == "" || compareAPIVersions(v, negotiated) > 0 {
+			negotiated = v
+		}
+	}
+	if negotiated == "" {
+		return "", fmt.Errorf("The cluster supports Element API versions %v, but the provider requires a version between %v and %v",
+			strings.Join(supported, ", "), MinimumAPIVersion, MaximumAPIVersion)
+	}
+
+	log.Printf("Using Element API version %v", negotiated)
+	c.SetAPIVersion(negotiated)
+	return negotiated, nil
+}
+
+// clusterAPIVersions asks the cluster for its supported API versions with GetAPI. Clusters that do not
+// implement GetAPI are assumed to support every version up to the one GetClusterVersionInfo reports.
+func (c *Client) clusterAPIVersions(ctx context.Context) ([]string, error) {
+	response, err := c.callAPIMethodAtVersion(ctx, discoveryAPIVersion, "GetAPI", nil)
+	if err == nil {
+		var result GetAPIResult
+		if err := json.Unmarshal([]byte(*response), &result); err != nil {
+			log.Print("Failed to unmarshal response from GetAPI")
+			return nil, err
+		}
+
+		var versions []string
+		for _, v := range result.SupportedVersions {
+			versions = append(versions, formatAPIVersion(v.String()))
+		}
+		if len(versions) > 0 {
+			return versions, nil
+		}
+	} else if errors.Is(err, ErrCanceled) || errors.Is(err, ErrUnauthorized) {
+		return nil, err
+	}
+
+	response, err = c.callAPIMethodAtVersion(ctx, discoveryAPIVersion, "GetClusterVersionInfo", nil)
+	if err != nil {
+		log.Print("GetClusterVersionInfo request failed")
+		return nil, err
+	}
+
+	var result GetClusterVersionInfoResult
+	if err := json.Unmarshal([]byte(*response), &result); err != nil {
+		log.Print("Failed to unmarshal response from GetClusterVersionInfo")
+		return nil, err
+	}
+	if result.ClusterAPIVersion == "" {
+		return nil, errors.New("Unable to determine the Element API version of the cluster")
+	}
+
+	var versions []string
+	clusterVersion := formatAPIVersion(result.ClusterAPIVersion)
+	major, _ := parseAPIVersion(MinimumAPIVersion)
+	clusterMajor, clusterMinor := parseAPIVersion(clusterVersion)
+	for ; major < clusterMajor; major++ {
+		versions = append(versions, fmt.Sprintf("%v.0", major))
+	}
+	for minor := 0; minor <= clusterMinor; minor++ {
+		versions = append(versions, fmt.Sprintf("%v.%v", clusterMajor, minor))
+	}
+	return versions, nil
+}
+
+// SupportsFeature reports whether the API version in use provides the feature
+func (c *Client) SupportsFeature(feature Feature) bool {
+	return compareAPIVersions(c.GetAPIVersion(), feature.MinAPIVersion) >= 0
+}
+
+// RequireFeature returns an error wrapping ErrUnsupported if the API version in use does not provide the feature
+func (c *Client) RequireFeature(feature Feature) error {
+	if c.SupportsFeature(feature) {
+		return nil
+	}
+	return fmt.Errorf("%v requires Element API %v or later, but the provider is using Element API %v: %w",
+		feature.Name, feature.MinAPIVersion, c.GetAPIVersion(), ErrUnsupported)
+}
+
+func parseAPIVersion(version string) (int, int) {
+	parts := strings.SplitN(version, ".", 2)
+	major, _ := strconv.Atoi(parts[0])
+	minor := 0
+	if len(parts) == 2 {
+		minor, _ = strconv.Atoi(parts[1])
+	}
+	return major, minor
+}
+
+func formatAPIVersion(version string) string {
+	major, minor := parseAPIVersion(version)
+	return fmt.Sprintf("%v.%v", major, minor)
+}
+
+// compareAPIVersions returns -1, 0 or 1 when a is older than, the same as, or newer than b
+func compareAPIVersions(a, b string) int {
+	aMajor, aMinor := parseAPIVersion(a)
+	bMajor, bMinor := parseAPIVersion(b)
+	switch {
+	case aMajor < bMajor, aMajor == bMajor && aMinor < bMinor:
+		return -1
+	case aMajor == bMajor && aMinor == bMinor:
+		return 0
+	}
+	return 1
+}
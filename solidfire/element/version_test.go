@@ -0,0 +1,89 @@
+package element
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestNegotiateAPIVersion(t *testing.T) {
+	defer gock.Off()
+
+	fakeHost := "http://fakehost"
+
+	gock.New(fakeHost).
+		Post("/json-rpc/1.0").
+		Persist().
+		Reply(http.StatusOK).
+		JSON(map[string]interface{}{
+			"result": map[string]interface{}{
+				"currentVersion":    99.0,
+				"supportedVersions": []interface{}{1.0, 8.0, 9.0, 10.0, "10.1", 99.0},
+			},
+		})
+
+	client := &Client{Host: fakeHost}
+
+	version, err := client.NegotiateAPIVersion(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "10.1", version)
+	assert.Equal(t, "10.1", client.GetAPIVersion())
+
+	version, err = client.NegotiateAPIVersion(context.Background(), "9.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "9.0", version)
+
+	_, err = client.NegotiateAPIVersion(context.Background(), "9.5")
+	assert.Error(t, err)
+}
+
+func TestNegotiateAPIVersionFallback(t *testing.T) {
+	defer gock.Off()
+
+	fakeHost := "http://fakehost"
+
+	gock.New(fakeHost).
+		Post("/json-rpc/1.0").
+		Reply(http.StatusOK).
+		JSON(map[string]interface{}{
+			"error": map[string]interface{}{
+				"name": "xUnknownAPIMethod",
+			},
+		})
+	gock.New(fakeHost).
+		Post("/json-rpc/1.0").
+		Reply(http.StatusOK).
+		JSON(map[string]interface{}{
+			"result": map[string]interface{}{
+				"clusterAPIVersion": "9.2",
+				"clusterVersion":    "9.2.0.43",
+			},
+		})
+
+	client := &Client{Host: fakeHost}
+
+	version, err := client.NegotiateAPIVersion(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "9.2", version)
+}
+
+func TestRequireFeature(t *testing.T) {
+	client := &Client{}
+	client.SetAPIVersion("9.0")
+
+	assert.NoError(t, client.RequireFeature(FeatureStorageContainers))
+
+	err := client.RequireFeature(FeatureQoSPolicies)
+	assert.True(t, errors.Is(err, ErrUnsupported))
+	assert.Contains(t, err.Error(), "requires Element API 10.0")
+}
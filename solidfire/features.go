@@ -0,0 +1,42 @@
+package solidfire
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element"
+)
+
+// requireFeature returns a CustomizeDiffFunc that fails the plan when the Element API version in use does
+// not provide feature. If fields are given, the feature is only required when one of them is set.
+func requireFeature(feature element.Feature, fields ...string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		client := meta.(*element.Client)
+
+		if len(fields) == 0 {
+			return client.RequireFeature(feature)
+		}
+
+		for _, field := range fields {
+			if _, ok := d.GetOk(field); ok {
+				if err := client.RequireFeature(feature); err != nil {
+					return fmt.Errorf("%v: %v", field, err)
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// customizeDiffAll runs each CustomizeDiffFunc in turn, stopping at the first error
+func customizeDiffAll(funcs ...schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		for _, f := range funcs {
+			if err := f(d, meta); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
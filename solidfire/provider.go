@@ -1,12 +1,14 @@
 package solidfire
 
 import (
+	"context"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"username": {
 				Type:        schema.TypeString,
@@ -28,30 +30,123 @@ func Provider() terraform.ResourceProvider {
 			},
 			"api_version": {
 				Type:        schema.TypeString,
-				Required:    true,
-				DefaultFunc: schema.EnvDefaultFunc("SOLIDFIRE_API_VERSION", nil),
-				Description: "The SolidFire server API version.",
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SOLIDFIRE_API_VERSION", ""),
+				Description: "The SolidFire server API version. Defaults to the highest version supported by both the cluster and the provider.",
+			},
+			"max_retries": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SOLIDFIRE_MAX_RETRIES", 3),
+				Description: "The maximum number of times a failed read-only API call is retried. Set to 0 to disable retries.",
+			},
+			"retry_min_backoff": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1,
+				Description: "The delay, in seconds, before the first retry of a failed API call.",
+			},
+			"retry_max_backoff": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     30,
+				Description: "The maximum delay, in seconds, between retries of a failed API call.",
+			},
+			"retryable_errors": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Additional Element API error names that are treated as transient and retried.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ca_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("SOLIDFIRE_CA_FILE", nil),
+				ConflictsWith: []string{"ca_cert", "insecure", "cert_fingerprint"},
+				Description:   "Path to a PEM encoded CA bundle used to verify the SolidFire server certificate.",
+			},
+			"ca_cert": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_file", "insecure", "cert_fingerprint"},
+				Description:   "PEM encoded CA bundle used to verify the SolidFire server certificate.",
+			},
+			"insecure": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("SOLIDFIRE_INSECURE", false),
+				ConflictsWith: []string{"ca_file", "ca_cert", "cert_fingerprint"},
+				Description:   "Skip verification of the SolidFire server certificate.",
+			},
+			"cert_fingerprint": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("SOLIDFIRE_CERT_FINGERPRINT", nil),
+				ConflictsWith: []string{"insecure", "ca_file", "ca_cert"},
+				Description:   "SHA-256 fingerprint of the SolidFire server certificate to pin.",
+			},
+			"tls_server_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The host name used to verify the SolidFire server certificate, if it differs from solidfire_server.",
 			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"solidfire_volume_access_group": resourceSolidFireVolumeAccessGroup(),
-			"solidfire_initiator":           resourceSolidFireInitiator(),
-			"solidfire_volume":              resourceSolidFireVolume(),
-			"solidfire_account":             resourceSolidFireAccount(),
+			"solidfire_volume_access_group":     resourceSolidFireVolumeAccessGroup(),
+			"solidfire_initiator":               resourceSolidFireInitiator(),
+			"solidfire_volume":                  resourceSolidFireVolume(),
+			"solidfire_volume_clone":            resourceSolidFireVolumeClone(),
+			"solidfire_account":                 resourceSolidFireAccount(),
+			"solidfire_snapshot":                resourceSolidFireSnapshot(),
+			"solidfire_group_snapshot":          resourceSolidFireGroupSnapshot(),
+			"solidfire_volume_rollback":         resourceSolidFireVolumeRollback(),
+			"solidfire_group_snapshot_rollback": resourceSolidFireGroupSnapshotRollback(),
+			"solidfire_schedule":                resourceSolidFireSchedule(),
+			"solidfire_qos_policy":              resourceSolidFireQoSPolicy(),
 		},
+	}
 
-		ConfigureFunc: providerConfigure,
+	provider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		return providerConfigure(d, provider.StopContext())
 	}
+
+	return provider
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+func providerConfigure(d *schema.ResourceData, stopCtx context.Context) (interface{}, error) {
 	config := Config{
 		User:            d.Get("username").(string),
 		Password:        d.Get("password").(string),
 		SolidFireServer: d.Get("solidfire_server").(string),
 		APIVersion:      d.Get("api_version").(string),
+		MaxRetries:      d.Get("max_retries").(int),
+		RetryMinBackoff: d.Get("retry_min_backoff").(int),
+		RetryMaxBackoff: d.Get("retry_max_backoff").(int),
+		StopContext:     stopCtx,
+		CAFile:          d.Get("ca_file").(string),
+		CACert:          d.Get("ca_cert").(string),
+		Insecure:        d.Get("insecure").(bool),
+		CertFingerprint: d.Get("cert_fingerprint").(string),
+		TLSServerName:   d.Get("tls_server_name").(string),
+	}
+
+	if raw, ok := d.GetOk("retryable_errors"); ok {
+		for _, v := range raw.([]interface{}) {
+			config.RetryableErrors = append(config.RetryableErrors, v.(string))
+		}
+	}
+
+	client, err := config.Client()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := client.NegotiateAPIVersion(client.Context(), config.APIVersion); err != nil {
+		return nil, err
 	}
 
-	return config.Client()
+	return client, nil
 }
@@ -1,16 +1,75 @@
 package solidfire
 
 import (
+	"log"
+	"net/http"
+	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
-	"os"
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element/fake"
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element/recorder"
 )
 
 var testAccProviders map[string]terraform.ResourceProvider
 var testAccProvider *schema.Provider
 
+// testAccFakeCluster is the fake cluster the acceptance tests run against, if SOLIDFIRE_FAKE_CLUSTER is set
+var testAccFakeCluster *fake.Server
+
+// TestMain prepares the environment of the acceptance tests:
+//
+//   - SOLIDFIRE_FAKE_CLUSTER runs them against an in-memory fake cluster, so they need no real cluster.
+//   - SOLIDFIRE_RECORD=<path> records every API interaction into a cassette at path.
+//   - SOLIDFIRE_REPLAY=<path> answers API calls from a cassette recorded earlier instead of a cluster.
+func TestMain(m *testing.M) {
+	var server *fake.Server
+	if os.Getenv("SOLIDFIRE_FAKE_CLUSTER") != "" {
+		server = fake.NewServer()
+		os.Setenv("SOLIDFIRE_SERVER", server.Host())
+		os.Setenv("SOLIDFIRE_USERNAME", server.Username)
+		os.Setenv("SOLIDFIRE_PASSWORD", server.Password)
+		os.Setenv("SOLIDFIRE_CERT_FINGERPRINT", server.CertFingerprint())
+		testAccFakeCluster = server
+	}
+
+	var rec *recorder.Recorder
+	cassette := os.Getenv("SOLIDFIRE_RECORD")
+	if cassette != "" {
+		rec = recorder.NewRecorder(nil)
+		wrapTransport = rec.Wrap
+	}
+
+	if path := os.Getenv("SOLIDFIRE_REPLAY"); path != "" {
+		replayer, err := recorder.LoadReplayer(path)
+		if err != nil {
+			log.Fatalf("Unable to load cassette: %v", err)
+		}
+		wrapTransport = func(http.RoundTripper) http.RoundTripper {
+			return replayer
+		}
+		for _, env := range []string{"SOLIDFIRE_SERVER", "SOLIDFIRE_USERNAME", "SOLIDFIRE_PASSWORD"} {
+			if os.Getenv(env) == "" {
+				os.Setenv(env, "replay")
+			}
+		}
+	}
+
+	code := m.Run()
+
+	if rec != nil {
+		if err := rec.Save(cassette); err != nil {
+			log.Printf("Unable to save cassette: %v", err)
+			code = 1
+		}
+	}
+	if server != nil {
+		server.Close()
+	}
+	os.Exit(code)
+}
+
 func TestProvider(t *testing.T) {
 	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -40,8 +99,13 @@ func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("SOLIDFIRE_SERVER"); v == "" {
 		t.Fatal("SOLIDFIRE_SERVER must be set for acceptance tests")
 	}
+}
+
+// testAccPreCheckFakeCluster skips tests that inject API errors, which only the fake cluster can do
+func testAccPreCheckFakeCluster(t *testing.T) {
+	testAccPreCheck(t)
 
-	if v := os.Getenv("SOLIDFIRE_API_VERSION"); v == "" {
-		t.Fatal("SOLIDFIRE_API_VERSION must be set for acceptance tests")
+	if testAccFakeCluster == nil {
+		t.Skip("SOLIDFIRE_FAKE_CLUSTER must be set for tests that inject API errors")
 	}
 }
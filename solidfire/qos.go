@@ -0,0 +1,42 @@
+package solidfire
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element"
+)
+
+// customizeDiffQoS validates min_iops, max_iops and burst_iops against each other and against the QoS limits of
+// the cluster, so invalid settings fail the plan instead of the apply. The limits are only fetched when one of
+// the arguments changes, and at most once per run.
+func customizeDiffQoS(d *schema.ResourceDiff, meta interface{}) error {
+	changed := false
+	for _, field := range []string{"min_iops", "max_iops", "burst_iops"} {
+		if d.HasChange(field) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	// Values that are not known yet read as zero and are left to the cluster to check
+	qos := element.QoS{
+		MinIOPS:   d.Get("min_iops").(int),
+		MaxIOPS:   d.Get("max_iops").(int),
+		BurstIOPS: d.Get("burst_iops").(int),
+	}
+
+	client := meta.(*element.Client)
+	limits, err := client.Limits(client.Context())
+	if err != nil {
+		return fmt.Errorf("Unable to get the QoS limits of the cluster: %v", err)
+	}
+
+	if err := limits.ValidateQoS(qos); err != nil {
+		return fmt.Errorf("Invalid QoS settings: %v", err)
+	}
+
+	return nil
+}
@@ -1,41 +1,20 @@
 package solidfire
 
 import (
+	"crypto/rand"
+	"errors"
 	"fmt"
 	"log"
+	"math/big"
+	"sort"
 	"strconv"
+	"strings"
 
-	"encoding/json"
-
-	"github.com/fatih/structs"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/solidfire/terraform-provider-solidfire/solidfire/element"
-	"github.com/solidfire/terraform-provider-solidfire/solidfire/element/jsonrpc"
 )
 
-type CreateAccountRequest struct {
-	Username        string      `structs:"username"`
-	InitiatorSecret string      `structs:"initiatorSecret,omitempty"`
-	TargetSecret    string      `structs:"targetSecret,omitempty"`
-	Attributes      interface{} `structs:"attributes,omitempty"`
-}
-
-type CreateAccountResult struct {
-	Account element.Account `json:"account"`
-}
-
-type ModifyAccountRequest struct {
-	AccountID       int         `structs:"accountID"`
-	InitiatorSecret string      `structs:"initiatorSecret,omitempty"`
-	TargetSecret    string      `structs:"targetSecret,omitempty"`
-	Attributes      interface{} `structs:"attributes,omitempty"`
-	Username        string      `structs:"username,omitempty"`
-}
-
-type RemoveAccountRequest struct {
-	AccountID int `structs:"accountID"`
-}
-
 func resourceSolidFireAccount() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceSolidFireAccountCreate,
@@ -46,6 +25,9 @@ func resourceSolidFireAccount() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		SchemaVersion: attributesSchemaVersion,
+		MigrateState:  migrateAttributesState,
+		CustomizeDiff: resourceSolidFireAccountCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"username": {
@@ -53,22 +35,51 @@ func resourceSolidFireAccount() *schema.Resource {
 				Required: true,
 			},
 			"initiator_secret": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				Sensitive:     true,
+				ValidateFunc:  validateCHAPSecret,
+				ConflictsWith: []string{"rotate_secrets_trigger"},
+			},
+			"target_secret": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				Sensitive:     true,
+				ValidateFunc:  validateCHAPSecret,
+				ConflictsWith: []string{"rotate_secrets_trigger"},
+			},
+			"rotate_secrets_trigger": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"initiator_secret", "target_secret"},
+			},
+			"status": {
 				Type:     schema.TypeString,
 				Optional: true,
 				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"active",
+					"locked",
+				}, false),
 			},
-			"target_secret": {
-				Type:     schema.TypeString,
+			"enable_chap": {
+				Type:     schema.TypeBool,
 				Optional: true,
 				Computed: true,
 			},
-			"attributes": {
-				Type:     schema.TypeList,
+			"storage_container_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"force_destroy": {
+				Type:     schema.TypeBool,
 				Optional: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
+				Default:  false,
 			},
+			"attributes":      attributesSchema(),
+			"attributes_json": attributesJSONSchema(),
 		},
 	}
 }
@@ -77,7 +88,7 @@ func resourceSolidFireAccountCreate(d *schema.ResourceData, meta interface{}) er
 	log.Printf("Creating account: %#v", d)
 	client := meta.(*element.Client)
 
-	acct := CreateAccountRequest{}
+	acct := element.AddAccountRequest{}
 
 	if v, ok := d.GetOk("username"); ok {
 		acct.Username = v.(string)
@@ -93,36 +104,43 @@ func resourceSolidFireAccountCreate(d *schema.ResourceData, meta interface{}) er
 		acct.TargetSecret = v.(string)
 	}
 
-	resp, err := createAccount(client, acct)
+	if v, ok := d.GetOkExists("enable_chap"); ok {
+		enableChap := v.(bool)
+		acct.EnableChap = &enableChap
+	}
+
+	attributes, err := expandAttributes(d)
 	if err != nil {
-		log.Print("Error creating account")
 		return err
 	}
+	if len(attributes) > 0 {
+		acct.Attributes = attributes
+	}
+
+	log.Printf("Parameters: %v", element.Redact(acct))
 
-	d.SetId(fmt.Sprintf("%v", resp.Account.AccountID))
+	resp, err := client.AddAccount(acct)
+	if err != nil {
+		log.Print("Error creating account")
+		return err
+	}
 
-	log.Printf("Created account: %v %v", acct.Username, resp.Account.AccountID)
+	d.SetId(fmt.Sprintf("%v", resp.AccountID))
 
-	return resourceSolidFireAccountRead(d, meta)
-}
+	log.Printf("Created account: %v %v", acct.Username, resp.AccountID)
 
-func createAccount(client *element.Client, request CreateAccountRequest) (CreateAccountResult, error) {
-	params := structs.Map(request)
+	// AddAccount always creates active accounts
+	if d.Get("status").(string) == "locked" {
+		modify := element.ModifyAccountRequest{AccountID: resp.AccountID, Status: "locked"}
 
-	log.Printf("Parameters: %v", params)
+		log.Printf("Parameters: %v", element.Redact(modify))
 
-	response, err := client.CallAPIMethod("AddAccount", params)
-	if err != nil {
-		log.Print("CreateAccount request failed")
-		return CreateAccountResult{}, err
+		if _, err := client.ModifyAccount(modify); err != nil {
+			return err
+		}
 	}
 
-	var result CreateAccountResult
-	if err := json.Unmarshal([]byte(*response), &result); err != nil {
-		log.Print("Failed to unmarshall response from CreateAccount")
-		return CreateAccountResult{}, err
-	}
-	return result, nil
+	return resourceSolidFireAccountRead(d, meta)
 }
 
 func resourceSolidFireAccountRead(d *schema.ResourceData, meta interface{}) error {
@@ -136,22 +154,32 @@ func resourceSolidFireAccountRead(d *schema.ResourceData, meta interface{}) erro
 		return fmt.Errorf("id argument is required")
 	}
 
-	res, err := client.GetAccountByID(convID)
+	res, err := client.GetAccountByID(element.GetAccountByIDRequest{AccountID: convID})
 	if err != nil {
+		if errors.Is(err, element.ErrNotFound) {
+			log.Printf("Account %v not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
 		log.Print("GetAccountByID failed")
 		return err
 	}
 
-	if _, ok := d.GetOk("username"); ok {
-		d.Set("username", res.Username)
-	}
+	d.Set("username", res.Account.Username)
+	d.Set("initiator_secret", res.Account.InitiatorSecret)
+	d.Set("target_secret", res.Account.TargetSecret)
+	d.Set("status", res.Account.Status)
+	d.Set("enable_chap", res.Account.EnableChap)
 
-	if _, ok := d.GetOk("initiator_secret"); ok {
-		d.Set("initiator_secret", res.InitiatorSecret)
+	// Storage containers are only reported from Element API 9.0
+	if client.SupportsFeature(element.FeatureStorageContainers) {
+		d.Set("storage_container_id", res.Account.StorageContainerID)
+	} else {
+		d.Set("storage_container_id", "")
 	}
 
-	if _, ok := d.GetOk("target_secret"); ok {
-		d.Set("target_secret", res.TargetSecret)
+	if err := setAttributes(d, res.Account.Attributes); err != nil {
+		return err
 	}
 
 	return nil
@@ -161,7 +189,7 @@ func resourceSolidFireAccountUpdate(d *schema.ResourceData, meta interface{}) er
 	log.Printf("Updating account %#v", d)
 	client := meta.(*element.Client)
 
-	acct := ModifyAccountRequest{}
+	acct := element.ModifyAccountRequest{}
 
 	id := d.Id()
 	convID, convErr := strconv.Atoi(id)
@@ -183,21 +211,88 @@ func resourceSolidFireAccountUpdate(d *schema.ResourceData, meta interface{}) er
 		acct.TargetSecret = v.(string)
 	}
 
-	err := modifyAccount(client, acct)
+	if d.HasChange("rotate_secrets_trigger") {
+		initiatorSecret, targetSecret, err := generateCHAPSecrets()
+		if err != nil {
+			return err
+		}
+		acct.InitiatorSecret = initiatorSecret
+		acct.TargetSecret = targetSecret
+		log.Printf("Rotating CHAP secrets of account %v", id)
+	}
+
+	if d.HasChange("status") {
+		acct.Status = d.Get("status").(string)
+		if acct.Status == "locked" {
+			log.Printf("[WARN] Locking account %v, its volumes will be inaccessible until it is unlocked", id)
+		}
+	}
+
+	if d.HasChange("enable_chap") {
+		enableChap := d.Get("enable_chap").(bool)
+		acct.EnableChap = &enableChap
+	}
+
+	if attributesChanged(d) {
+		attributes, err := expandAttributes(d)
+		if err != nil {
+			return err
+		}
+		acct.Attributes = attributes
+	}
+
+	log.Printf("Parameters: %v", element.Redact(acct))
+
+	// Keep the old values in state if the change fails, so that a secret rotation is retried by the next apply
+	d.Partial(true)
+	_, err := client.ModifyAccount(acct)
 	if err != nil {
 		return err
 	}
+	d.Partial(false)
 
-	return nil
+	return resourceSolidFireAccountRead(d, meta)
+}
+
+const chapSecretCharacters = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+// generateCHAPSecrets returns a new pair of random initiator and target secrets of the maximum length the
+// cluster accepts. The cluster rejects accounts whose initiator and target secrets are the same.
+func generateCHAPSecrets() (string, string, error) {
+	var secrets [2]string
+	for secrets[0] == secrets[1] {
+		for i := range secrets {
+			secret := make([]byte, chapSecretLengthMax)
+			for j := range secret {
+				n, err := rand.Int(rand.Reader, big.NewInt(int64(len(chapSecretCharacters))))
+				if err != nil {
+					return "", "", fmt.Errorf("Unable to generate a CHAP secret: %v", err)
+				}
+				secret[j] = chapSecretCharacters[n.Int64()]
+			}
+			secrets[i] = string(secret)
+		}
+	}
+	return secrets[0], secrets[1], nil
 }
 
-func modifyAccount(client *element.Client, request ModifyAccountRequest) error {
-	params := structs.Map(request)
+// resourceSolidFireAccountCustomizeDiff rejects plans that set the same initiator and target secret, and marks
+// both secrets as changing when rotate_secrets_trigger changes
+func resourceSolidFireAccountCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.NewValueKnown("initiator_secret") && d.NewValueKnown("target_secret") {
+		initiatorSecret := d.Get("initiator_secret").(string)
+		if initiatorSecret != "" && initiatorSecret == d.Get("target_secret").(string) {
+			return fmt.Errorf("initiator_secret and target_secret must be different")
+		}
+	}
 
-	_, err := client.CallAPIMethod("ModifyAccount", params)
-	if err != nil {
-		log.Print("ModifyAccount request failed")
-		return err
+	if d.Id() != "" && d.HasChange("rotate_secrets_trigger") {
+		if err := d.SetNewComputed("initiator_secret"); err != nil {
+			return err
+		}
+		if err := d.SetNewComputed("target_secret"); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -207,30 +302,100 @@ func resourceSolidFireAccountDelete(d *schema.ResourceData, meta interface{}) er
 	log.Printf("Deleting account: %#v", d)
 	client := meta.(*element.Client)
 
-	acct := RemoveAccountRequest{}
-
 	id := d.Id()
 	convID, convErr := strconv.Atoi(id)
 
 	if convErr != nil {
 		return fmt.Errorf("id argument is required")
 	}
-	acct.AccountID = convID
 
-	err := removeAccount(client, acct)
+	volumes, err := accountVolumes(client, convID)
+	if err != nil {
+		if errors.Is(err, element.ErrNotFound) {
+			log.Printf("Account %v has already been deleted", id)
+			return nil
+		}
+		return err
+	}
+
+	if len(volumes) > 0 {
+		if !d.Get("force_destroy").(bool) {
+			return fmt.Errorf("Account %v cannot be destroyed because it still owns volumes: %v. Delete and purge the "+
+				"volumes first, or set force_destroy to delete them with the account", id, describeVolumes(volumes))
+		}
+
+		for _, volume := range volumes {
+			if err := purgeVolume(client, volume); err != nil {
+				return fmt.Errorf("Unable to purge volume %v of account %v: %v", volume.VolumeID, id, err)
+			}
+		}
+	}
+
+	_, err = client.RemoveAccount(element.RemoveAccountRequest{AccountID: convID})
 	if err != nil {
+		if errors.Is(err, element.ErrNotFound) {
+			log.Printf("Account %v has already been deleted", id)
+			return nil
+		}
 		return err
 	}
 
 	return nil
 }
 
-func removeAccount(client *element.Client, request RemoveAccountRequest) error {
-	params := structs.Map(request)
+// accountVolumes returns the active and deleted but not yet purged volumes owned by an account, sorted by ID.
+// Both keep the cluster from removing the account.
+func accountVolumes(client *element.Client, accountID int) ([]element.Volume, error) {
+	res, err := client.ListVolumesForAccount(element.ListVolumesForAccountRequest{AccountID: accountID})
+	if err != nil {
+		return nil, err
+	}
 
-	_, err := client.CallAPIMethod("RemoveAccount", params)
+	deleted, err := client.ListDeletedVolumes(element.ListDeletedVolumesRequest{})
 	if err != nil {
-		log.Print("DeleteAccount request failed")
+		return nil, err
+	}
+
+	byID := map[int]element.Volume{}
+	for _, volume := range res.Volumes {
+		byID[volume.VolumeID] = volume
+	}
+	for _, volume := range deleted.Volumes {
+		if volume.AccountID == accountID {
+			byID[volume.VolumeID] = volume
+		}
+	}
+
+	volumes := make([]element.Volume, 0, len(byID))
+	for _, volume := range byID {
+		volumes = append(volumes, volume)
+	}
+	sort.Slice(volumes, func(i, j int) bool { return volumes[i].VolumeID < volumes[j].VolumeID })
+
+	return volumes, nil
+}
+
+func describeVolumes(volumes []element.Volume) string {
+	descriptions := make([]string, len(volumes))
+	for i, volume := range volumes {
+		descriptions[i] = fmt.Sprintf("%v (ID %v, %v)", volume.Name, volume.VolumeID, volume.Status)
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// purgeVolume deletes a volume if it is still active and purges it, so it can no longer be restored
+func purgeVolume(client *element.Client, volume element.Volume) error {
+	log.Printf("[WARN] Purging volume %v (%v) of account %v", volume.VolumeID, volume.Name, volume.AccountID)
+
+	if volume.Status != "deleted" {
+		_, err := client.DeleteVolume(element.DeleteVolumeRequest{VolumeID: volume.VolumeID})
+		if err != nil && !errors.Is(err, element.ErrNotFound) {
+			return err
+		}
+	}
+
+	_, err := client.PurgeDeletedVolume(element.PurgeDeletedVolumeRequest{VolumeID: volume.VolumeID})
+	if err != nil && !errors.Is(err, element.ErrNotFound) {
 		return err
 	}
 
@@ -248,13 +413,11 @@ func resourceSolidFireAccountExists(d *schema.ResourceData, meta interface{}) (b
 		return false, fmt.Errorf("id argument is required")
 	}
 
-	_, err := client.GetAccountByID(convID)
+	_, err := client.GetAccountByID(element.GetAccountByIDRequest{AccountID: convID})
 	if err != nil {
-		if err, ok := err.(*jsonrpc.ResponseError); ok {
-			if err.Name == "xUnknownAccount" {
-				d.SetId("")
-				return false, nil
-			}
+		if errors.Is(err, element.ErrNotFound) {
+			d.SetId("")
+			return false, nil
 		}
 		log.Print("AccountExists failed")
 		return false, err
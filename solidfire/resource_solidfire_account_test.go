@@ -1,6 +1,8 @@
 package solidfire
 
 import (
+	"reflect"
+	"regexp"
 	"strconv"
 	"testing"
 
@@ -26,8 +28,8 @@ func TestAccount_basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckSolidFireAccountExists("solidfire_account.terraform-acceptance-account-1", &account),
 					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "username", "terraform-acceptance-test"),
-					resource.TestCheckResourceAttrSet("solidfire_account.terraform-acceptance-account-1", "targetSecret"),
-					resource.TestCheckResourceAttrSet("solidfire_account.terraform-acceptance-account-1", "initiatorSecret"),
+					resource.TestCheckResourceAttrSet("solidfire_account.terraform-acceptance-account-1", "target_secret"),
+					resource.TestCheckResourceAttrSet("solidfire_account.terraform-acceptance-account-1", "initiator_secret"),
 					resource.TestCheckResourceAttrSet("solidfire_account.terraform-acceptance-account-1", "id"),
 				),
 			},
@@ -52,10 +54,167 @@ func TestAccount_secrets(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckSolidFireAccountExists("solidfire_account.terraform-acceptance-account-1", &account),
 					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "username", "terraform-acceptance-test"),
-					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "targetSecret", "ABC123456XYZ"),
-					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "initiatorSecret", "SecretSecret1"),
+					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "target_secret", "ABC123456XYZ"),
+					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "initiator_secret", "SecretSecret1"),
 				),
 			},
+			{
+				ResourceName:            "solidfire_account.terraform-acceptance-account-1",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"rotate_secrets_trigger", "force_destroy"},
+			},
+			{
+				Config: fmt.Sprintf(
+					testAccCheckSolidFireAccountConfigSecrets,
+					"terraform-acceptance-test",
+					"ABC123456XYZ",
+					"Short1",
+				),
+				ExpectError: regexp.MustCompile("must be 12 to 16 characters long"),
+			},
+			{
+				Config: fmt.Sprintf(
+					testAccCheckSolidFireAccountConfigSecrets,
+					"terraform-acceptance-test",
+					"ABC123456XYZ",
+					"ABC123456XYZ",
+				),
+				ExpectError: regexp.MustCompile("initiator_secret and target_secret must be different"),
+			},
+		},
+	})
+}
+
+func TestAccount_rotateSecrets(t *testing.T) {
+	var account, rotated element.Account
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireAccountConfigRotate, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireAccountExists("solidfire_account.terraform-acceptance-account-1", &account),
+					testAccCheckSolidFireAccountSecrets("solidfire_account.terraform-acceptance-account-1", &account),
+				),
+			},
+			{
+				// Applying the same trigger again does not rotate the secrets
+				Config:   fmt.Sprintf(testAccCheckSolidFireAccountConfigRotate, "1"),
+				PlanOnly: true,
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireAccountConfigRotate, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireAccountExists("solidfire_account.terraform-acceptance-account-1", &rotated),
+					testAccCheckSolidFireAccountSecrets("solidfire_account.terraform-acceptance-account-1", &rotated),
+					testAccCheckSolidFireAccountSecretsRotated(&account, &rotated),
+				),
+			},
+		},
+	})
+}
+
+func TestAccount_rotateSecretsFailed(t *testing.T) {
+	var account, rotated element.Account
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckFakeCluster(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireAccountConfigRotate, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireAccountExists("solidfire_account.terraform-acceptance-account-1", &account),
+				),
+			},
+			{
+				PreConfig: func() {
+					testAccFakeCluster.InjectError("ModifyAccount", "xServiceUnavailable")
+				},
+				Config:      fmt.Sprintf(testAccCheckSolidFireAccountConfigRotate, "2"),
+				ExpectError: regexp.MustCompile("xServiceUnavailable"),
+			},
+			{
+				// The failed rotation did not record the new trigger, so it is retried
+				Config: fmt.Sprintf(testAccCheckSolidFireAccountConfigRotate, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireAccountExists("solidfire_account.terraform-acceptance-account-1", &rotated),
+					testAccCheckSolidFireAccountSecretsRotated(&account, &rotated),
+				),
+			},
+		},
+	})
+}
+
+func TestAccount_secretDrift(t *testing.T) {
+	var account element.Account
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(
+					testAccCheckSolidFireAccountConfig,
+					"terraform-acceptance-test",
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireAccountExists("solidfire_account.terraform-acceptance-account-1", &account),
+				),
+			},
+			{
+				// Secrets the cluster generated are read back, so refreshing after an out-of-band change
+				// updates the state
+				PreConfig: func() {
+					virConn := testAccProvider.Meta().(*element.Client)
+					_, err := virConn.ModifyAccount(element.ModifyAccountRequest{
+						AccountID:       account.AccountID,
+						InitiatorSecret: "ChangedSecret1",
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: fmt.Sprintf(
+					testAccCheckSolidFireAccountConfig,
+					"terraform-acceptance-test",
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "initiator_secret", "ChangedSecret1"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(
+					testAccCheckSolidFireAccountConfigSecrets,
+					"terraform-acceptance-test",
+					"ABC123456XYZ",
+					"SecretSecret1",
+				),
+			},
+			{
+				// Configured secrets that were changed on the cluster show up in the plan
+				PreConfig: func() {
+					virConn := testAccProvider.Meta().(*element.Client)
+					_, err := virConn.ModifyAccount(element.ModifyAccountRequest{
+						AccountID:    account.AccountID,
+						TargetSecret: "ChangedSecret2",
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: fmt.Sprintf(
+					testAccCheckSolidFireAccountConfigSecrets,
+					"terraform-acceptance-test",
+					"ABC123456XYZ",
+					"SecretSecret1",
+				),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
 		},
 	})
 }
@@ -77,20 +236,152 @@ func TestAccount_update(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckSolidFireAccountExists("solidfire_account.terraform-acceptance-account-1", &account),
 					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "username", "terraform-acceptance-test"),
-					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "targetSecret", "ABC123456XYZ"),
-					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "initiatorSecret", "SecretSecret1"),
+					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "target_secret", "ABC123456XYZ"),
+					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "initiator_secret", "SecretSecret1"),
 				),
 			},
 			{
 				Config: fmt.Sprintf(
-					testAccCheckSolidFireAccountConfigUpdate,
+					testAccCheckSolidFireAccountConfigSecrets,
 					"terraform-acceptance-test-update",
+					"ABC123456XYZU",
+					"SecretSecret1U",
 				),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckSolidFireAccountExists("solidfire_account.terraform-acceptance-account-1", &account),
 					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "username", "terraform-acceptance-test-update"),
-					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "targetSecret", "ABC123456XYZU"),
-					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "initiatorSecret", "SecretSecret1U"),
+					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "target_secret", "ABC123456XYZU"),
+					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "initiator_secret", "SecretSecret1U"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccount_status(t *testing.T) {
+	var account element.Account
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireAccountConfigStatus, "locked", "false"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireAccountExists("solidfire_account.terraform-acceptance-account-1", &account),
+					testAccCheckSolidFireAccountStatus(&account, "locked", false),
+					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "status", "locked"),
+					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "enable_chap", "false"),
+					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "storage_container_id", "00000000-0000-0000-0000-000000000000"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireAccountConfigStatus, "active", "true"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireAccountExists("solidfire_account.terraform-acceptance-account-1", &account),
+					testAccCheckSolidFireAccountStatus(&account, "active", true),
+					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "status", "active"),
+					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "enable_chap", "true"),
+				),
+			},
+			{
+				PreConfig: func() {
+					virConn := testAccProvider.Meta().(*element.Client)
+					enableChap := false
+					_, err := virConn.ModifyAccount(element.ModifyAccountRequest{
+						AccountID:  account.AccountID,
+						Status:     "locked",
+						EnableChap: &enableChap,
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             fmt.Sprintf(testAccCheckSolidFireAccountConfigStatus, "active", "true"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccount_forceDestroy(t *testing.T) {
+	var volume element.Volume
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireAccountConfigForceDestroy, "false", testAccCheckSolidFireAccountVolumeConfig),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireVolumeExists("solidfire_volume.terraform-acceptance-test-1", &volume),
+				),
+			},
+			{
+				// The volume is only soft-deleted, so it still pins the account
+				Config: fmt.Sprintf(testAccCheckSolidFireAccountConfigForceDestroy, "false", ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireVolumeSoftDeleted(&volume),
+				),
+			},
+			{
+				Config:      testAccCheckSolidFireAccountConfigOther,
+				ExpectError: regexp.MustCompile("still owns volumes: terraform-acceptance-test-force \\(ID \\d+, deleted\\)"),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireAccountConfigForceDestroy, "true", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "force_destroy", "true"),
+				),
+			},
+			{
+				Config: testAccCheckSolidFireAccountConfigOther,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireVolumePurged(&volume),
+				),
+			},
+		},
+	})
+}
+
+func TestAccount_attributes(t *testing.T) {
+	var account element.Account
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireAccountConfigAttributes, `attributes = {
+		owner = "ops"
+		tier = "gold"
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireAccountExists("solidfire_account.terraform-acceptance-account-1", &account),
+					testAccCheckSolidFireAccountAttributes(&account, map[string]interface{}{"owner": "ops", "tier": "gold"}),
+					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "attributes.%", "2"),
+					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "attributes.owner", "ops"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireAccountConfigAttributes, `attributes_json = <<EOF
+{"owner": "ops", "backup": {"policy": "daily", "copies": 2}}
+EOF`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireAccountExists("solidfire_account.terraform-acceptance-account-1", &account),
+					testAccCheckSolidFireAccountAttributes(&account, map[string]interface{}{
+						"owner":  "ops",
+						"backup": map[string]interface{}{"policy": "daily", "copies": float64(2)},
+					}),
+					resource.TestCheckResourceAttr("solidfire_account.terraform-acceptance-account-1", "attributes.%", "0"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireAccountConfigAttributes, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireAccountExists("solidfire_account.terraform-acceptance-account-1", &account),
+					testAccCheckSolidFireAccountAttributes(&account, map[string]interface{}{}),
 				),
 			},
 		},
@@ -110,7 +401,7 @@ func testAccCheckSolidFireAccountDestroy(s *terraform.State) error {
 			return convErr
 		}
 
-		_, err := virConn.GetAccountByID(convID)
+		_, err := virConn.GetAccountByID(element.GetAccountByIDRequest{AccountID: convID})
 		if err == nil {
 			return fmt.Errorf("Error waiting for volume (%s) to be destroyed: %s", rs.Primary.ID, err)
 		}
@@ -137,21 +428,101 @@ func testAccCheckSolidFireAccountExists(n string, account *element.Account) reso
 			return err
 		}
 
-		retrievedAcc, err := virConn.GetAccountByID(convID)
+		retrievedAcc, err := virConn.GetAccountByID(element.GetAccountByIDRequest{AccountID: convID})
 		if err != nil {
 			return err
 		}
 
-		if retrievedAcc.AccountID != convID {
+		if retrievedAcc.Account.AccountID != convID {
 			return fmt.Errorf("Resource ID and account ID do not match")
 		}
 
-		*account = retrievedAcc
+		*account = retrievedAcc.Account
+
+		return nil
+	}
+}
+
+func testAccCheckSolidFireAccountStatus(account *element.Account, status string, enableChap bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if account.Status != status {
+			return fmt.Errorf("Expected account status %v, got %v", status, account.Status)
+		}
+
+		if account.EnableChap != enableChap {
+			return fmt.Errorf("Expected enableChap %v, got %v", enableChap, account.EnableChap)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckSolidFireVolumePurged checks that volume is neither active nor deleted
+func testAccCheckSolidFireVolumePurged(volume *element.Volume) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		virConn := testAccProvider.Meta().(*element.Client)
+
+		if _, err := virConn.GetVolumeByID(strconv.Itoa(volume.VolumeID)); err == nil {
+			return fmt.Errorf("Volume %v still exists", volume.VolumeID)
+		}
+
+		res, err := virConn.ListDeletedVolumes(element.ListDeletedVolumesRequest{})
+		if err != nil {
+			return err
+		}
+
+		for _, v := range res.Volumes {
+			if v.VolumeID == volume.VolumeID {
+				return fmt.Errorf("Volume %v is deleted but not purged", volume.VolumeID)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckSolidFireAccountAttributes(account *element.Account, attributes map[string]interface{}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		actual, _ := account.Attributes.(map[string]interface{})
+		if actual == nil {
+			actual = map[string]interface{}{}
+		}
+
+		if !reflect.DeepEqual(actual, attributes) {
+			return fmt.Errorf("Expected attributes %v, got %v", attributes, actual)
+		}
 
 		return nil
 	}
 }
 
+// testAccCheckSolidFireAccountSecrets checks that the secrets in the state match the secrets of account
+func testAccCheckSolidFireAccountSecrets(n string, account *element.Account) resource.TestCheckFunc {
+	return resource.ComposeTestCheckFunc(
+		func(s *terraform.State) error {
+			if account.InitiatorSecret == account.TargetSecret {
+				return fmt.Errorf("Expected different initiator and target secrets")
+			}
+			return nil
+		},
+		func(s *terraform.State) error {
+			return resource.TestCheckResourceAttr(n, "initiator_secret", account.InitiatorSecret)(s)
+		},
+		func(s *terraform.State) error {
+			return resource.TestCheckResourceAttr(n, "target_secret", account.TargetSecret)(s)
+		},
+	)
+}
+
+func testAccCheckSolidFireAccountSecretsRotated(before *element.Account, after *element.Account) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if before.InitiatorSecret == after.InitiatorSecret || before.TargetSecret == after.TargetSecret {
+			return fmt.Errorf("Expected the secrets of account %v to be rotated", after.AccountID)
+		}
+		return nil
+	}
+}
+
 const testAccCheckSolidFireAccountConfig = `
 resource "solidfire_account" "terraform-acceptance-account-1" {
 	username = "%s"
@@ -161,13 +532,52 @@ resource "solidfire_account" "terraform-acceptance-account-1" {
 const testAccCheckSolidFireAccountConfigSecrets = `
 resource "solidfire_account" "terraform-acceptance-account-1" {
 	username = "%s"
-	targetSecret = "%s"
-	initiatorSecret = "%s"
+	target_secret = "%s"
+	initiator_secret = "%s"
 }
 `
 
-const testAccCheckSolidFireAccountConfigUpdate = `
+const testAccCheckSolidFireAccountConfigRotate = `
 resource "solidfire_account" "terraform-acceptance-account-1" {
-	username = "%s"
+	username = "terraform-acceptance-test"
+	rotate_secrets_trigger = "%s"
+}
+`
+
+const testAccCheckSolidFireAccountConfigStatus = `
+resource "solidfire_account" "terraform-acceptance-account-1" {
+	username = "terraform-acceptance-test"
+	status = "%s"
+	enable_chap = %s
+}
+`
+
+const testAccCheckSolidFireAccountConfigForceDestroy = `
+resource "solidfire_account" "terraform-acceptance-account-1" {
+	username = "terraform-acceptance-test"
+	force_destroy = %s
+}
+%s`
+
+const testAccCheckSolidFireAccountVolumeConfig = `
+resource "solidfire_volume" "terraform-acceptance-test-1" {
+	name = "terraform-acceptance-test-force"
+	account_id = "${solidfire_account.terraform-acceptance-account-1.id}"
+	total_size = "1073741824"
+	enable512e = "true"
+	delete_behavior = "soft-delete"
+}
+`
+
+const testAccCheckSolidFireAccountConfigOther = `
+resource "solidfire_account" "terraform-acceptance-account-2" {
+	username = "terraform-acceptance-test-other"
+}
+`
+
+const testAccCheckSolidFireAccountConfigAttributes = `
+resource "solidfire_account" "terraform-acceptance-account-1" {
+	username = "terraform-acceptance-test"
+	%s
 }
 `
@@ -0,0 +1,378 @@
+package solidfire
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element"
+)
+
+func resourceSolidFireGroupSnapshot() *schema.Resource {
+	attributes := attributesSchema()
+	attributes.ForceNew = true
+	attributesJSON := attributesJSONSchema()
+	attributesJSON.ForceNew = true
+
+	return &schema.Resource{
+		Create: resourceSolidFireGroupSnapshotCreate,
+		Read:   resourceSolidFireGroupSnapshotRead,
+		Update: resourceSolidFireGroupSnapshotUpdate,
+		Delete: resourceSolidFireGroupSnapshotDelete,
+		Exists: resourceSolidFireGroupSnapshotExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceSolidFireGroupSnapshotImport,
+		},
+		CustomizeDiff: customizeDiffAll(
+			requireFeature(element.FeatureSnapMirror, "snap_mirror_label"),
+			customizeDiffRetentionRemoval,
+		),
+
+		Schema: map[string]*schema.Schema{
+			"volume_ids": {
+				Type:     schema.TypeSet,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Set:      schema.HashInt,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"retention": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validateRetention,
+				ConflictsWith: []string{"expiration_time"},
+			},
+			"expiration_time": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validateRFC3339,
+				ConflictsWith: []string{"retention"},
+			},
+			"enable_remote_replication": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"snap_mirror_label": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// ModifyGroupSnapshot cannot change the attributes of a group snapshot
+			"attributes":      attributes,
+			"attributes_json": attributesJSON,
+			"save_members": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"group_snapshot_uuid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"create_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"members": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"volume_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"snapshot_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"snapshot_uuid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"checksum": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"snapshot_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+		},
+	}
+}
+
+func resourceSolidFireGroupSnapshotCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Creating group snapshot: %#v", d)
+	client := meta.(*element.Client)
+
+	group := element.CreateGroupSnapshotRequest{}
+
+	if v, ok := d.GetOk("volume_ids"); ok {
+		for _, id := range v.(*schema.Set).List() {
+			group.Volumes = append(group.Volumes, id.(int))
+		}
+	} else {
+		return fmt.Errorf("volume_ids argument is required")
+	}
+
+	if v, ok := d.GetOk("name"); ok {
+		group.Name = v.(string)
+	}
+
+	if v, ok := d.GetOk("retention"); ok {
+		group.Retention = v.(string)
+	}
+
+	if v, ok := d.GetOk("enable_remote_replication"); ok {
+		group.EnableRemoteReplication = v.(bool)
+	}
+
+	if v, ok := d.GetOk("snap_mirror_label"); ok {
+		group.SnapMirrorLabel = v.(string)
+	}
+
+	attributes, err := expandAttributes(d)
+	if err != nil {
+		return err
+	}
+	if len(attributes) > 0 {
+		group.Attributes = attributes
+	}
+
+	log.Printf("Parameters: %v", element.Redact(group))
+
+	resp, err := client.CreateGroupSnapshot(group)
+	if err != nil {
+		log.Print("Error creating group snapshot")
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%v", resp.GroupSnapshotID))
+	log.Printf("Created group snapshot: %v %v", resp.GroupSnapshot.Name, resp.GroupSnapshotID)
+
+	// CreateGroupSnapshot only accepts a retention period, so a fixed expiration time is set afterwards
+	if v, ok := d.GetOk("expiration_time"); ok {
+		modify := element.ModifyGroupSnapshotRequest{GroupSnapshotID: resp.GroupSnapshotID, ExpirationTime: v.(string)}
+
+		log.Printf("Parameters: %v", element.Redact(modify))
+
+		if _, err := client.ModifyGroupSnapshot(modify); err != nil {
+			return err
+		}
+	}
+
+	return resourceSolidFireGroupSnapshotRead(d, meta)
+}
+
+func resourceSolidFireGroupSnapshotRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Reading group snapshot: %#v", d)
+	client := meta.(*element.Client)
+
+	id := d.Id()
+	convID, convErr := strconv.Atoi(id)
+
+	if convErr != nil {
+		return fmt.Errorf("id argument is required")
+	}
+
+	res, err := client.ListGroupSnapshots(element.ListGroupSnapshotsRequest{GroupSnapshotID: convID})
+	if err != nil {
+		if errors.Is(err, element.ErrNotFound) {
+			log.Printf("Group snapshot %v not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	if len(res.GroupSnapshots) == 0 {
+		log.Printf("Group snapshot %v not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	if len(res.GroupSnapshots) != 1 {
+		return fmt.Errorf("Expected one Group Snapshot to be found. Response contained %v results", len(res.GroupSnapshots))
+	}
+
+	group := res.GroupSnapshots[0]
+
+	volumeIDs := make([]int, 0, len(group.Members))
+	members := make([]map[string]interface{}, 0, len(group.Members))
+	snapshotIDs := map[string]interface{}{}
+	for _, member := range group.Members {
+		volumeIDs = append(volumeIDs, member.VolumeID)
+		members = append(members, map[string]interface{}{
+			"volume_id":     member.VolumeID,
+			"snapshot_id":   member.SnapshotID,
+			"snapshot_uuid": member.SnapshotUUID,
+			"checksum":      member.Checksum,
+		})
+		snapshotIDs[strconv.Itoa(member.VolumeID)] = member.SnapshotID
+	}
+
+	d.Set("volume_ids", volumeIDs)
+	d.Set("name", group.Name)
+	d.Set("enable_remote_replication", group.EnableRemoteReplication)
+	d.Set("group_snapshot_uuid", group.GroupSnapshotUUID)
+	d.Set("create_time", group.CreateTime)
+	d.Set("status", group.Status)
+	if err := d.Set("members", members); err != nil {
+		return err
+	}
+	if err := d.Set("snapshot_ids", snapshotIDs); err != nil {
+		return err
+	}
+
+	// The expiration and SnapMirror label of a group snapshot are stored on its members
+	if len(group.Members) > 0 {
+		d.Set("expiration_time", group.Members[0].ExpirationTime)
+		d.Set("snap_mirror_label", group.Members[0].SnapMirrorLabel)
+	}
+
+	if err := setAttributes(d, group.Attributes); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceSolidFireGroupSnapshotUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Updating group snapshot %#v", d)
+	client := meta.(*element.Client)
+
+	group := element.ModifyGroupSnapshotRequest{}
+	changed := false
+
+	id := d.Id()
+	convID, convErr := strconv.Atoi(id)
+
+	if convErr != nil {
+		return fmt.Errorf("id argument is required")
+	}
+	group.GroupSnapshotID = convID
+
+	if d.HasChange("name") {
+		group.Name = d.Get("name").(string)
+		changed = true
+	}
+
+	if d.HasChange("enable_remote_replication") {
+		enable := d.Get("enable_remote_replication").(bool)
+		group.EnableRemoteReplication = &enable
+		changed = true
+	}
+
+	if d.HasChange("snap_mirror_label") {
+		group.SnapMirrorLabel = d.Get("snap_mirror_label").(string)
+		changed = true
+	}
+
+	if v, ok := d.GetOk("retention"); ok && d.HasChange("retention") {
+		expiration, err := retentionExpirationTime(d.Get("create_time").(string), v.(string))
+		if err != nil {
+			return fmt.Errorf("Unable to set retention of group snapshot %v: %v", id, err)
+		}
+		group.ExpirationTime = expiration
+		changed = true
+	}
+
+	if v, ok := d.GetOk("expiration_time"); ok && d.HasChange("expiration_time") {
+		group.ExpirationTime = v.(string)
+		changed = true
+	}
+
+	if changed {
+		log.Printf("Parameters: %v", element.Redact(group))
+
+		_, err := client.ModifyGroupSnapshot(group)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceSolidFireGroupSnapshotRead(d, meta)
+}
+
+func resourceSolidFireGroupSnapshotDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Deleting group snapshot: %#v", d)
+	client := meta.(*element.Client)
+
+	id := d.Id()
+	convID, convErr := strconv.Atoi(id)
+
+	if convErr != nil {
+		return fmt.Errorf("id argument is required")
+	}
+
+	group := element.DeleteGroupSnapshotRequest{
+		GroupSnapshotID: convID,
+		SaveMembers:     d.Get("save_members").(bool),
+	}
+
+	log.Printf("Parameters: %v", element.Redact(group))
+
+	_, err := client.DeleteGroupSnapshot(group)
+	if err != nil {
+		if errors.Is(err, element.ErrNotFound) {
+			log.Printf("Group snapshot %v has already expired or been deleted", id)
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+func resourceSolidFireGroupSnapshotExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	log.Printf("Checking existence of group snapshot: %#v", d)
+	client := meta.(*element.Client)
+
+	id := d.Id()
+	convID, convErr := strconv.Atoi(id)
+
+	if convErr != nil {
+		return false, fmt.Errorf("id argument is required")
+	}
+
+	res, err := client.ListGroupSnapshots(element.ListGroupSnapshotsRequest{GroupSnapshotID: convID})
+	if err != nil {
+		if errors.Is(err, element.ErrNotFound) {
+			d.SetId("")
+			return false, nil
+		}
+		return false, err
+	}
+
+	if len(res.GroupSnapshots) != 1 {
+		d.SetId("")
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// resourceSolidFireGroupSnapshotImport imports a group snapshot by ID. save_members is not stored on the
+// cluster, so it starts out at its default.
+func resourceSolidFireGroupSnapshotImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("save_members", false)
+	return []*schema.ResourceData{d}, nil
+}
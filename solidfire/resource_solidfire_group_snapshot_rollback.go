@@ -0,0 +1,162 @@
+package solidfire
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element"
+)
+
+// resourceSolidFireGroupSnapshotRollback rolls the volumes of a group snapshot back to it when it is created,
+// and again whenever group_snapshot_id or trigger change. Destroying it leaves the volumes as they are.
+func resourceSolidFireGroupSnapshotRollback() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceSolidFireGroupSnapshotRollbackCreate,
+		Read:          resourceSolidFireGroupSnapshotRollbackRead,
+		Update:        resourceSolidFireGroupSnapshotRollbackUpdate,
+		Delete:        resourceSolidFireGroupSnapshotRollbackDelete,
+		CustomizeDiff: customizeDiffRollback("group_snapshot_id", "saved_group_snapshot_id"),
+
+		Schema: map[string]*schema.Schema{
+			"group_snapshot_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"save_current_state": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"saved_snapshot_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"saved_group_snapshot_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"volume_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+		},
+	}
+}
+
+func resourceSolidFireGroupSnapshotRollbackCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Creating group snapshot rollback: %#v", d)
+
+	if err := rollbackGroupSnapshot(d, meta.(*element.Client)); err != nil {
+		return err
+	}
+
+	return resourceSolidFireGroupSnapshotRollbackRead(d, meta)
+}
+
+func resourceSolidFireGroupSnapshotRollbackRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Reading group snapshot rollback: %#v", d)
+	client := meta.(*element.Client)
+
+	// The rollback itself leaves nothing to read. It is only dropped from state when all of its volumes are
+	// gone, and not when the group snapshot expires, so that an expired group snapshot does not cause another
+	// rollback.
+	var volumeIDs []int
+	for _, v := range d.Get("volume_ids").([]interface{}) {
+		volumeIDs = append(volumeIDs, v.(int))
+	}
+	if len(volumeIDs) == 0 {
+		return nil
+	}
+
+	// ListVolumes fails if any of the volumes is gone, so they are looked up one at a time
+	for _, volumeID := range volumeIDs {
+		res, err := client.ListVolumes(element.ListVolumesRequest{VolumeIDs: []int{volumeID}})
+		if err != nil {
+			if errors.Is(err, element.ErrNotFound) {
+				continue
+			}
+			return err
+		}
+		if len(res.Volumes) == 1 && res.Volumes[0].Status != "deleted" {
+			return nil
+		}
+	}
+
+	log.Printf("Volumes %v not found, removing group snapshot rollback %v from state", volumeIDs, d.Id())
+	d.SetId("")
+	return nil
+}
+
+func resourceSolidFireGroupSnapshotRollbackUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Updating group snapshot rollback %#v", d)
+
+	if d.HasChange("group_snapshot_id") || d.HasChange("trigger") {
+		// Keep the old values in state if the rollback fails, so that the next apply retries it
+		d.Partial(true)
+		if err := rollbackGroupSnapshot(d, meta.(*element.Client)); err != nil {
+			return err
+		}
+		d.Partial(false)
+	}
+
+	return resourceSolidFireGroupSnapshotRollbackRead(d, meta)
+}
+
+func resourceSolidFireGroupSnapshotRollbackDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Deleting group snapshot rollback: %#v", d)
+	log.Printf("[INFO] Volumes %v are left at group snapshot %v; destroying a rollback does not undo it", d.Get("volume_ids"), d.Get("group_snapshot_id"))
+	return nil
+}
+
+// rollbackGroupSnapshot rolls the volumes of the configured group snapshot back and records the volumes and
+// the safety group snapshot, if any
+func rollbackGroupSnapshot(d *schema.ResourceData, client *element.Client) error {
+	rollback := element.RollbackToGroupSnapshotRequest{
+		GroupSnapshotID:  d.Get("group_snapshot_id").(int),
+		SaveCurrentState: d.Get("save_current_state").(bool),
+	}
+
+	if v, ok := d.GetOk("saved_snapshot_name"); ok && rollback.SaveCurrentState {
+		rollback.Name = v.(string)
+	}
+
+	res, err := client.ListGroupSnapshots(element.ListGroupSnapshotsRequest{GroupSnapshotID: rollback.GroupSnapshotID})
+	if err != nil {
+		return err
+	}
+	if len(res.GroupSnapshots) != 1 {
+		return fmt.Errorf("Group snapshot %v not found", rollback.GroupSnapshotID)
+	}
+
+	volumeIDs := make([]int, 0, len(res.GroupSnapshots[0].Members))
+	for _, member := range res.GroupSnapshots[0].Members {
+		volumeIDs = append(volumeIDs, member.VolumeID)
+	}
+
+	log.Printf("Parameters: %v", element.Redact(rollback))
+
+	resp, err := client.RollbackToGroupSnapshot(rollback)
+	if err != nil {
+		log.Print("Error rolling back group snapshot")
+		return err
+	}
+
+	log.Printf("Rolled back volumes %v to group snapshot %v", volumeIDs, rollback.GroupSnapshotID)
+	if rollback.SaveCurrentState {
+		log.Printf("Saved state of volumes %v before rollback as group snapshot %v", volumeIDs, resp.GroupSnapshotID)
+	}
+
+	d.SetId(strconv.Itoa(rollback.GroupSnapshotID))
+	d.Set("saved_group_snapshot_id", resp.GroupSnapshotID)
+	d.Set("volume_ids", volumeIDs)
+
+	return nil
+}
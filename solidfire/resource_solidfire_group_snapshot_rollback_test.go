@@ -0,0 +1,113 @@
+package solidfire
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element"
+)
+
+func TestGroupSnapshotRollback_trigger(t *testing.T) {
+	var saved int
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireVolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireGroupSnapshotRollbackConfig, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireRollbackSaved("solidfire_group_snapshot_rollback.terraform-acceptance-test-1", "saved_group_snapshot_id", &saved, true),
+					testAccCheckSolidFireSavedGroupSnapshot(&saved, 2),
+					resource.TestCheckResourceAttr("solidfire_group_snapshot_rollback.terraform-acceptance-test-1", "volume_ids.#", "2"),
+				),
+			},
+			{
+				// An unchanged trigger does not roll back again
+				Config: fmt.Sprintf(testAccCheckSolidFireGroupSnapshotRollbackConfig, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireRollbackSaved("solidfire_group_snapshot_rollback.terraform-acceptance-test-1", "saved_group_snapshot_id", &saved, false),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireGroupSnapshotRollbackConfig, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireRollbackSaved("solidfire_group_snapshot_rollback.terraform-acceptance-test-1", "saved_group_snapshot_id", &saved, true),
+					testAccCheckSolidFireSavedGroupSnapshot(&saved, 2),
+				),
+			},
+		},
+	})
+}
+
+func TestGroupSnapshotRollback_failed(t *testing.T) {
+	var saved int
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckFakeCluster(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireVolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireGroupSnapshotRollbackConfig, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireRollbackSaved("solidfire_group_snapshot_rollback.terraform-acceptance-test-1", "saved_group_snapshot_id", &saved, true),
+					testAccCheckSolidFireSavedGroupSnapshot(&saved, 2),
+				),
+			},
+			{
+				PreConfig: func() {
+					testAccFakeCluster.InjectError("RollbackToGroupSnapshot", "xSnapshotBusy")
+				},
+				Config:      fmt.Sprintf(testAccCheckSolidFireGroupSnapshotRollbackConfig, "2"),
+				ExpectError: regexp.MustCompile("xSnapshotBusy"),
+			},
+			{
+				// The failed rollback did not record the new trigger, so it is retried
+				Config: fmt.Sprintf(testAccCheckSolidFireGroupSnapshotRollbackConfig, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireRollbackSaved("solidfire_group_snapshot_rollback.terraform-acceptance-test-1", "saved_group_snapshot_id", &saved, true),
+					testAccCheckSolidFireSavedGroupSnapshot(&saved, 2),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSolidFireSavedGroupSnapshot(id *int, members int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		virConn := testAccProvider.Meta().(*element.Client)
+
+		res, err := virConn.ListGroupSnapshots(element.ListGroupSnapshotsRequest{GroupSnapshotID: *id})
+		if err != nil {
+			return err
+		}
+		if len(res.GroupSnapshots) != 1 {
+			return fmt.Errorf("Safety group snapshot %v not found", *id)
+		}
+		if len(res.GroupSnapshots[0].Members) != members {
+			return fmt.Errorf("Expected safety group snapshot %v to have %v members, got %v", *id, members, len(res.GroupSnapshots[0].Members))
+		}
+
+		// The safety group snapshot is not managed by Terraform, so it is deleted here
+		_, err = virConn.DeleteGroupSnapshot(element.DeleteGroupSnapshotRequest{GroupSnapshotID: *id})
+		if err != nil {
+			return fmt.Errorf("Unable to delete safety group snapshot %v: %v", *id, err)
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSolidFireGroupSnapshotRollbackConfig = `
+resource "solidfire_group_snapshot_rollback" "terraform-acceptance-test-1" {
+	group_snapshot_id = "${solidfire_group_snapshot.terraform-acceptance-test-1.id}"
+	trigger = "%s"
+	save_current_state = true
+}
+resource "solidfire_group_snapshot" "terraform-acceptance-test-1" {
+	volume_ids = ["${solidfire_volume.terraform-acceptance-test-1.id}", "${solidfire_volume.terraform-acceptance-test-2.id}"]
+}
+` + testAccCheckSolidFireGroupSnapshotVolumesConfig
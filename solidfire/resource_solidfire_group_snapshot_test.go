@@ -0,0 +1,225 @@
+package solidfire
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element"
+)
+
+func TestGroupSnapshot_basic(t *testing.T) {
+	var group element.GroupSnapshot
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireGroupSnapshotDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireGroupSnapshotConfig, `
+	name = "terraform-acceptance-test"
+	retention = "24:00:00"
+	attributes = {
+		app = "db"
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireGroupSnapshotExists("solidfire_group_snapshot.terraform-acceptance-test-1", &group),
+					testAccCheckSolidFireGroupSnapshotMembers(&group, 24*time.Hour),
+					resource.TestCheckResourceAttr("solidfire_group_snapshot.terraform-acceptance-test-1", "name", "terraform-acceptance-test"),
+					resource.TestCheckResourceAttr("solidfire_group_snapshot.terraform-acceptance-test-1", "volume_ids.#", "2"),
+					resource.TestCheckResourceAttr("solidfire_group_snapshot.terraform-acceptance-test-1", "members.#", "2"),
+					resource.TestCheckResourceAttr("solidfire_group_snapshot.terraform-acceptance-test-1", "snapshot_ids.%", "2"),
+					resource.TestCheckResourceAttr("solidfire_group_snapshot.terraform-acceptance-test-1", "attributes.app", "db"),
+					resource.TestCheckResourceAttrSet("solidfire_group_snapshot.terraform-acceptance-test-1", "members.0.snapshot_id"),
+					resource.TestCheckResourceAttrSet("solidfire_group_snapshot.terraform-acceptance-test-1", "group_snapshot_uuid"),
+					resource.TestCheckResourceAttrSet("solidfire_group_snapshot.terraform-acceptance-test-1", "create_time"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireGroupSnapshotConfig, `
+	name = "terraform-acceptance-test-update"
+	retention = "72:00:00"
+	enable_remote_replication = true
+	attributes = {
+		app = "db"
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireGroupSnapshotExists("solidfire_group_snapshot.terraform-acceptance-test-1", &group),
+					testAccCheckSolidFireGroupSnapshotMembers(&group, 72*time.Hour),
+					resource.TestCheckResourceAttr("solidfire_group_snapshot.terraform-acceptance-test-1", "name", "terraform-acceptance-test-update"),
+					resource.TestCheckResourceAttr("solidfire_group_snapshot.terraform-acceptance-test-1", "enable_remote_replication", "true"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireGroupSnapshotConfig, `
+	name = "terraform-acceptance-test-update"
+	enable_remote_replication = true
+	attributes = {
+		app = "db"
+	}`),
+				ExpectError: regexp.MustCompile("retention cannot be removed"),
+			},
+			{
+				ResourceName:            "solidfire_group_snapshot.terraform-acceptance-test-1",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"retention"},
+			},
+		},
+	})
+}
+
+func TestGroupSnapshot_saveMembers(t *testing.T) {
+	var group element.GroupSnapshot
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireGroupSnapshotDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireGroupSnapshotConfig, `save_members = true`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireGroupSnapshotExists("solidfire_group_snapshot.terraform-acceptance-test-1", &group),
+				),
+			},
+			{
+				// Keep the volumes so that the saved member snapshots can be checked
+				Config: testAccCheckSolidFireGroupSnapshotVolumesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireGroupSnapshotMembersSaved(&group),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSolidFireGroupSnapshotDestroy(s *terraform.State) error {
+	virConn := testAccProvider.Meta().(*element.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "solidfire_group_snapshot" {
+			continue
+		}
+
+		convID, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		res, err := virConn.ListGroupSnapshots(element.ListGroupSnapshotsRequest{GroupSnapshotID: convID})
+		if err == nil && len(res.GroupSnapshots) > 0 {
+			return fmt.Errorf("Error waiting for group snapshot (%s) to be destroyed", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckSolidFireGroupSnapshotExists(n string, group *element.GroupSnapshot) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		virConn := testAccProvider.Meta().(*element.Client)
+
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No SolidFire group snapshot key ID is set")
+		}
+
+		convID, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		res, err := virConn.ListGroupSnapshots(element.ListGroupSnapshotsRequest{GroupSnapshotID: convID})
+		if err != nil {
+			return err
+		}
+
+		if len(res.GroupSnapshots) != 1 || res.GroupSnapshots[0].GroupSnapshotID != convID {
+			return fmt.Errorf("Resource ID and group snapshot ID do not match")
+		}
+
+		*group = res.GroupSnapshots[0]
+
+		return nil
+	}
+}
+
+// testAccCheckSolidFireGroupSnapshotMembers checks that every member of group expires retention after the
+// group snapshot was taken
+func testAccCheckSolidFireGroupSnapshotMembers(group *element.GroupSnapshot, retention time.Duration) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if len(group.Members) != 2 {
+			return fmt.Errorf("Expected 2 members, got %v", len(group.Members))
+		}
+
+		for i := range group.Members {
+			if group.Members[i].GroupID != group.GroupSnapshotID {
+				return fmt.Errorf("Snapshot %v is not a member of group snapshot %v", group.Members[i].SnapshotID, group.GroupSnapshotID)
+			}
+			if err := testAccCheckSolidFireSnapshotRetention(&group.Members[i], retention)(s); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckSolidFireGroupSnapshotMembersSaved checks that the members of a destroyed group snapshot were
+// kept as individual snapshots, and deletes them
+func testAccCheckSolidFireGroupSnapshotMembersSaved(group *element.GroupSnapshot) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		virConn := testAccProvider.Meta().(*element.Client)
+
+		for _, member := range group.Members {
+			res, err := virConn.ListSnapshots(element.ListSnapshotsRequest{SnapshotID: member.SnapshotID})
+			if err != nil {
+				return err
+			}
+			if len(res.Snapshots) != 1 {
+				return fmt.Errorf("Member snapshot %v was not saved", member.SnapshotID)
+			}
+			if res.Snapshots[0].GroupID != 0 {
+				return fmt.Errorf("Member snapshot %v still belongs to group %v", member.SnapshotID, res.Snapshots[0].GroupID)
+			}
+			if _, err := virConn.DeleteSnapshot(element.DeleteSnapshotRequest{SnapshotID: member.SnapshotID}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSolidFireGroupSnapshotConfig = `
+resource "solidfire_group_snapshot" "terraform-acceptance-test-1" {
+	volume_ids = ["${solidfire_volume.terraform-acceptance-test-1.id}", "${solidfire_volume.terraform-acceptance-test-2.id}"]
+	%s
+}
+` + testAccCheckSolidFireGroupSnapshotVolumesConfig
+
+const testAccCheckSolidFireGroupSnapshotVolumesConfig = `
+resource "solidfire_volume" "terraform-acceptance-test-1" {
+	name = "terraform-acceptance-test-group-data"
+	account_id = "${solidfire_account.terraform-acceptance-test-1.id}"
+	total_size = "1073741824"
+	enable512e = "true"
+}
+resource "solidfire_volume" "terraform-acceptance-test-2" {
+	name = "terraform-acceptance-test-group-log"
+	account_id = "${solidfire_account.terraform-acceptance-test-1.id}"
+	total_size = "1073741824"
+	enable512e = "true"
+}
+resource "solidfire_account" "terraform-acceptance-test-1" {
+	username = "terraform-acceptance-test-group"
+}
+`
@@ -1,15 +1,13 @@
 package solidfire
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
 
-	"github.com/fatih/structs"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/solidfire/terraform-provider-solidfire/solidfire/element"
-	"github.com/solidfire/terraform-provider-solidfire/solidfire/element/jsonrpc"
 )
 
 type StorageDevice struct {
@@ -17,22 +15,6 @@ type StorageDevice struct {
 	IQN    string
 }
 
-type CreateInitiatorsRequest struct {
-	Initiators []element.Initiator `structs:"initiators"`
-}
-
-type CreateInitiatorsResult struct {
-	Initiators []element.InitiatorResponse `json:"initiators"`
-}
-
-type DeleteInitiatorsRequest struct {
-	Initiators []int `structs:"initiators"`
-}
-
-type ModifyInitiatorsRequest struct {
-	Initiators []element.Initiator `structs:"initiators"`
-}
-
 func resourceSolidFireInitiator() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceSolidFireInitiatorCreate,
@@ -43,6 +25,8 @@ func resourceSolidFireInitiator() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		SchemaVersion: attributesSchemaVersion,
+		MigrateState:  migrateAttributesState,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -53,13 +37,8 @@ func resourceSolidFireInitiator() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
-			"attributes": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-			},
+			"attributes":      attributesSchema(),
+			"attributes_json": attributesJSONSchema(),
 			"volume_access_group_id": {
 				Type:     schema.TypeInt,
 				Optional: true,
@@ -79,8 +58,8 @@ func resourceSolidFireInitiatorCreate(d *schema.ResourceData, meta interface{})
 	log.Printf("Creating initiator: %#v", d)
 	client := meta.(*element.Client)
 
-	initiators := CreateInitiatorsRequest{}
-	newInitiator := make([]element.Initiator, 1)
+	initiators := element.CreateInitiatorsRequest{}
+	newInitiator := make([]element.CreateInitiator, 1)
 	var iqns []string
 
 	if v, ok := d.GetOk("name"); ok {
@@ -97,6 +76,14 @@ func resourceSolidFireInitiatorCreate(d *schema.ResourceData, meta interface{})
 		newInitiator[0].VolumeAccessGroupID = v.(int)
 	}
 
+	attributes, err := expandAttributes(d)
+	if err != nil {
+		return err
+	}
+	if len(attributes) > 0 {
+		newInitiator[0].Attributes = attributes
+	}
+
 	if v, ok := d.GetOk("iqns"); ok {
 
 		if a, ok := v.([]interface{}); ok {
@@ -108,99 +95,71 @@ func resourceSolidFireInitiatorCreate(d *schema.ResourceData, meta interface{})
 
 	initiators.Initiators = newInitiator
 
-	resp, err := createInitiators(client, initiators)
+	log.Printf("Parameters: %v", element.Redact(initiators))
+
+	resp, err := client.CreateInitiators(initiators)
 	if err != nil {
 		log.Print("Error creating initiator")
 		return err
 	}
 
-	d.SetId(fmt.Sprintf("%v", resp.Initiators[0].ID))
-	log.Printf("Created initiator: %v %v", newInitiator[0].Name, resp.Initiators[0].ID)
+	d.SetId(fmt.Sprintf("%v", resp.Initiators[0].InitiatorID))
+	log.Printf("Created initiator: %v %v", newInitiator[0].Name, resp.Initiators[0].InitiatorID)
 
 	return resourceSolidFireInitiatorRead(d, meta)
 }
 
-func createInitiators(client *element.Client, request CreateInitiatorsRequest) (CreateInitiatorsResult, error) {
-	params := structs.Map(request)
-
-	log.Printf("Parameters: %v", params)
-
-	response, err := client.CallAPIMethod("CreateInitiators", params)
-	if err != nil {
-		log.Print("CreateInitiators request failed")
-		return CreateInitiatorsResult{}, err
-	}
-
-	var result CreateInitiatorsResult
-	if err := json.Unmarshal([]byte(*response), &result); err != nil {
-		log.Print("Failed to unmarshall resposne from CreateInitiators")
-		return CreateInitiatorsResult{}, err
-	}
-	log.Printf("Initiator Result: %v", result)
-	return result, nil
-}
-
 func resourceSolidFireInitiatorRead(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("Reading initiator: %#v", d)
 	client := meta.(*element.Client)
 
-	initiators := element.ListInitiatorRequest{}
-
 	id := d.Id()
-	s := make([]int, 1)
 	convID, convErr := strconv.Atoi(id)
 
 	if convErr != nil {
 		return fmt.Errorf("id argument is required")
 	}
 
-	s[0] = convID
-	initiators.Initiators = s
-
-	res, err := listInitiators(client, initiators)
+	res, err := client.ListInitiators(element.ListInitiatorsRequest{Initiators: []int{convID}})
 	if err != nil {
+		if errors.Is(err, element.ErrNotFound) {
+			log.Printf("Initiator %v not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 
+	if len(res.Initiators) == 0 {
+		log.Printf("Initiator %v not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
 	if len(res.Initiators) != 1 {
 		return fmt.Errorf("Expected one Initiator to be found. Response contained %v results", len(res.Initiators))
 	}
 
-	d.Set("name", res.Initiators[0].Name)
+	d.Set("name", res.Initiators[0].InitiatorName)
 	d.Set("alias", res.Initiators[0].Alias)
-	d.Set("attributes", res.Initiators[0].Attributes)
 
 	if len(res.Initiators[0].VolumeAccessGroups) == 1 {
 		d.Set("volume_access_group_id", res.Initiators[0].VolumeAccessGroups[0])
 	}
 
-	return nil
-}
-
-func listInitiators(client *element.Client, request element.ListInitiatorRequest) (element.ListInitiatorResult, error) {
-	params := structs.Map(request)
-
-	response, err := client.CallAPIMethod("ListInitiators", params)
-	if err != nil {
-		log.Print("ListInitiators request failed")
-		return element.ListInitiatorResult{}, err
-	}
-
-	var result element.ListInitiatorResult
-	if err := json.Unmarshal([]byte(*response), &result); err != nil {
-		log.Print("Failed to unmarshall response from ListInitiators")
-		return element.ListInitiatorResult{}, err
+	if err := setAttributes(d, res.Initiators[0].Attributes); err != nil {
+		return err
 	}
 
-	return result, nil
+	return nil
 }
 
 func resourceSolidFireInitiatorUpdate(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("Updating initiator: %#v", d)
 	client := meta.(*element.Client)
 
-	initiators := ModifyInitiatorsRequest{}
-	initiator := make([]element.Initiator, 1)
+	initiators := element.ModifyInitiatorsRequest{}
+	initiator := make([]element.ModifyInitiator, 1)
 
 	id := d.Id()
 	convID, convErr := strconv.Atoi(id)
@@ -219,22 +178,20 @@ func resourceSolidFireInitiatorUpdate(d *schema.ResourceData, meta interface{})
 		initiator[0].VolumeAccessGroupID = v.(int)
 	}
 
-	initiators.Initiators = initiator
-
-	err := modifyInitiators(client, initiators)
-	if err != nil {
-		return err
+	if attributesChanged(d) {
+		attributes, err := expandAttributes(d)
+		if err != nil {
+			return err
+		}
+		initiator[0].Attributes = attributes
 	}
 
-	return nil
-}
+	initiators.Initiators = initiator
 
-func modifyInitiators(client *element.Client, request ModifyInitiatorsRequest) error {
-	params := structs.Map(request)
+	log.Printf("Parameters: %v", element.Redact(initiators))
 
-	_, err := client.CallAPIMethod("ModifyInitiators", params)
+	_, err := client.ModifyInitiators(initiators)
 	if err != nil {
-		log.Print("ModifyInitiators request failed")
 		return err
 	}
 
@@ -245,33 +202,15 @@ func resourceSolidFireInitiatorDelete(d *schema.ResourceData, meta interface{})
 	log.Printf("Deleting initiator: %#v", d)
 	client := meta.(*element.Client)
 
-	initiators := DeleteInitiatorsRequest{}
-
 	id := d.Id()
-	s := make([]int, 1)
 	convID, convErr := strconv.Atoi(id)
 
 	if convErr != nil {
 		return fmt.Errorf("id argument is required")
 	}
 
-	s[0] = convID
-	initiators.Initiators = s
-
-	err := deleteInitiator(client, initiators)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func deleteInitiator(client *element.Client, request DeleteInitiatorsRequest) error {
-	params := structs.Map(request)
-
-	_, err := client.CallAPIMethod("DeleteInitiators", params)
+	_, err := client.DeleteInitiators(element.DeleteInitiatorsRequest{Initiators: []int{convID}})
 	if err != nil {
-		log.Print("DeleteInitiator request failed")
 		return err
 	}
 
@@ -282,26 +221,18 @@ func resourceSolidFireInitiatorExists(d *schema.ResourceData, meta interface{})
 	log.Printf("Checking existence of initiator: %#v", d)
 	client := meta.(*element.Client)
 
-	initiators := element.ListInitiatorRequest{}
-
 	id := d.Id()
-	s := make([]int, 1)
 	convID, convErr := strconv.Atoi(id)
 
 	if convErr != nil {
 		return false, fmt.Errorf("id argument is required")
 	}
 
-	s[0] = convID
-	initiators.Initiators = s
-
-	res, err := listInitiators(client, initiators)
+	res, err := client.ListInitiators(element.ListInitiatorsRequest{Initiators: []int{convID}})
 	if err != nil {
-		if err, ok := err.(*jsonrpc.ResponseError); ok {
-			if err.Name == "xUnknown" {
-				d.SetId("")
-				return false, nil
-			}
+		if errors.Is(err, element.ErrNotFound) {
+			d.SetId("")
+			return false, nil
 		}
 		return false, err
 	}
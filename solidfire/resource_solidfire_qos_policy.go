@@ -0,0 +1,227 @@
+package solidfire
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element"
+)
+
+func resourceSolidFireQoSPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSolidFireQoSPolicyCreate,
+		Read:   resourceSolidFireQoSPolicyRead,
+		Update: resourceSolidFireQoSPolicyUpdate,
+		Delete: resourceSolidFireQoSPolicyDelete,
+		Exists: resourceSolidFireQoSPolicyExists,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: customizeDiffAll(
+			requireFeature(element.FeatureQoSPolicies),
+			customizeDiffQoS,
+		),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"min_iops": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"max_iops": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"burst_iops": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"volume_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+		},
+	}
+}
+
+func resourceSolidFireQoSPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Creating QoS policy: %#v", d)
+	client := meta.(*element.Client)
+
+	policy := element.CreateQoSPolicyRequest{}
+
+	if v, ok := d.GetOk("name"); ok {
+		policy.Name = v.(string)
+	} else {
+		return fmt.Errorf("name argument is required")
+	}
+
+	policy.QoS = element.QoS{
+		MinIOPS:   d.Get("min_iops").(int),
+		MaxIOPS:   d.Get("max_iops").(int),
+		BurstIOPS: d.Get("burst_iops").(int),
+	}
+
+	log.Printf("Parameters: %v", element.Redact(policy))
+
+	resp, err := client.CreateQoSPolicy(policy)
+	if err != nil {
+		log.Print("Error creating QoS policy")
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%v", resp.QoSPolicy.QoSPolicyID))
+	log.Printf("Created QoS policy: %v %v", policy.Name, resp.QoSPolicy.QoSPolicyID)
+
+	return resourceSolidFireQoSPolicyRead(d, meta)
+}
+
+// getQoSPolicy returns the QoS policy with the given ID. Element 10 has no method to get a single policy, so
+// all policies are listed.
+func getQoSPolicy(client *element.Client, id int) (*element.QoSPolicy, error) {
+	res, err := client.ListQoSPolicies(element.ListQoSPoliciesRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range res.QoSPolicies {
+		if res.QoSPolicies[i].QoSPolicyID == id {
+			return &res.QoSPolicies[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+func resourceSolidFireQoSPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Reading QoS policy: %#v", d)
+	client := meta.(*element.Client)
+
+	id := d.Id()
+	convID, convErr := strconv.Atoi(id)
+
+	if convErr != nil {
+		return fmt.Errorf("id argument is required")
+	}
+
+	policy, err := getQoSPolicy(client, convID)
+	if err != nil {
+		return err
+	}
+
+	if policy == nil {
+		log.Printf("QoS policy %v not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", policy.Name)
+	d.Set("min_iops", policy.QoS.MinIOPS)
+	d.Set("max_iops", policy.QoS.MaxIOPS)
+	d.Set("burst_iops", policy.QoS.BurstIOPS)
+
+	if err := d.Set("volume_ids", policy.VolumeIDs); err != nil {
+		return fmt.Errorf("Error setting volume_ids: %v", err)
+	}
+
+	return nil
+}
+
+func resourceSolidFireQoSPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Updating QoS policy %#v", d)
+	client := meta.(*element.Client)
+
+	policy := element.ModifyQoSPolicyRequest{}
+	changed := false
+
+	id := d.Id()
+	convID, convErr := strconv.Atoi(id)
+
+	if convErr != nil {
+		return fmt.Errorf("id argument is required")
+	}
+	policy.QoSPolicyID = convID
+
+	if d.HasChange("name") {
+		policy.Name = d.Get("name").(string)
+		changed = true
+	}
+
+	// The cluster applies the new limits to all volumes associated with the policy
+	if d.HasChange("min_iops") || d.HasChange("max_iops") || d.HasChange("burst_iops") {
+		policy.QoS = &element.QoS{
+			MinIOPS:   d.Get("min_iops").(int),
+			MaxIOPS:   d.Get("max_iops").(int),
+			BurstIOPS: d.Get("burst_iops").(int),
+		}
+		changed = true
+	}
+
+	if changed {
+		log.Printf("Parameters: %v", element.Redact(policy))
+
+		_, err := client.ModifyQoSPolicy(policy)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceSolidFireQoSPolicyRead(d, meta)
+}
+
+func resourceSolidFireQoSPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Deleting QoS policy: %#v", d)
+	client := meta.(*element.Client)
+
+	id := d.Id()
+	convID, convErr := strconv.Atoi(id)
+
+	if convErr != nil {
+		return fmt.Errorf("id argument is required")
+	}
+
+	_, err := client.DeleteQoSPolicy(element.DeleteQoSPolicyRequest{QoSPolicyID: convID})
+	if err != nil {
+		if errors.Is(err, element.ErrNotFound) {
+			log.Printf("QoS policy %v has already been deleted", id)
+			return nil
+		}
+		if errors.Is(err, element.ErrInUse) {
+			return fmt.Errorf("QoS policy %v is still associated with volumes %v: %v", id, d.Get("volume_ids"), err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func resourceSolidFireQoSPolicyExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	log.Printf("Checking existence of QoS policy: %#v", d)
+	client := meta.(*element.Client)
+
+	id := d.Id()
+	convID, convErr := strconv.Atoi(id)
+
+	if convErr != nil {
+		return false, fmt.Errorf("id argument is required")
+	}
+
+	policy, err := getQoSPolicy(client, convID)
+	if err != nil {
+		return false, err
+	}
+
+	if policy == nil {
+		d.SetId("")
+		return false, nil
+	}
+
+	return true, nil
+}
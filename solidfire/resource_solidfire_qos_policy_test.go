@@ -0,0 +1,149 @@
+package solidfire
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element"
+)
+
+func TestQoSPolicy_basic(t *testing.T) {
+	var policy element.QoSPolicy
+	var volume element.Volume
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireQoSPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireQoSPolicyConfig, "500", "10000", "12000",
+					`qos_policy_id = "${solidfire_qos_policy.terraform-acceptance-test-1.id}"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireQoSPolicyExists("solidfire_qos_policy.terraform-acceptance-test-1", &policy),
+					testAccCheckSolidFireVolumeExists("solidfire_volume.terraform-acceptance-test-1", &volume),
+					testAccCheckSolidFireVolumeAttributes(&volume, 1073741824, 500, 10000, 12000),
+					resource.TestCheckResourceAttr("solidfire_qos_policy.terraform-acceptance-test-1", "name", "terraform-acceptance-test"),
+					resource.TestCheckResourceAttr("solidfire_qos_policy.terraform-acceptance-test-1", "min_iops", "500"),
+					resource.TestCheckResourceAttr("solidfire_qos_policy.terraform-acceptance-test-1", "volume_ids.#", "1"),
+					resource.TestCheckResourceAttrPair("solidfire_volume.terraform-acceptance-test-1", "qos_policy_id", "solidfire_qos_policy.terraform-acceptance-test-1", "id"),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "min_iops", "500"),
+				),
+			},
+			{
+				// Changing the limits of the policy updates its volumes without changes to their configuration
+				Config: fmt.Sprintf(testAccCheckSolidFireQoSPolicyConfig, "1000", "15000", "20000",
+					`qos_policy_id = "${solidfire_qos_policy.terraform-acceptance-test-1.id}"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireQoSPolicyExists("solidfire_qos_policy.terraform-acceptance-test-1", &policy),
+					testAccCheckSolidFireVolumeExists("solidfire_volume.terraform-acceptance-test-1", &volume),
+					testAccCheckSolidFireVolumeAttributes(&volume, 1073741824, 1000, 15000, 20000),
+					resource.TestCheckResourceAttr("solidfire_qos_policy.terraform-acceptance-test-1", "max_iops", "15000"),
+				),
+			},
+			{
+				// Detaching the volume from the policy applies the inline QoS settings
+				Config: fmt.Sprintf(testAccCheckSolidFireQoSPolicyConfig, "1000", "15000", "20000",
+					`min_iops = 200`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireQoSPolicyExists("solidfire_qos_policy.terraform-acceptance-test-1", &policy),
+					testAccCheckSolidFireVolumeExists("solidfire_volume.terraform-acceptance-test-1", &volume),
+					testAccCheckSolidFireVolumeAttributes(&volume, 1073741824, 200, 15000, 20000),
+					resource.TestCheckResourceAttr("solidfire_qos_policy.terraform-acceptance-test-1", "volume_ids.#", "0"),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "qos_policy_id", "0"),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "min_iops", "200"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireQoSPolicyConfig, "1000", "15000", "12000",
+					`min_iops = 200`),
+				ExpectError: regexp.MustCompile("maxIOPS \\(15000\\) must not be greater than burstIOPS \\(12000\\)"),
+			},
+			{
+				ResourceName:      "solidfire_qos_policy.terraform-acceptance-test-1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckSolidFireQoSPolicyDestroy(s *terraform.State) error {
+	virConn := testAccProvider.Meta().(*element.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "solidfire_qos_policy" {
+			continue
+		}
+
+		convID, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		policy, err := getQoSPolicy(virConn, convID)
+		if err != nil {
+			return err
+		}
+		if policy != nil {
+			return fmt.Errorf("Error waiting for QoS policy (%s) to be destroyed", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckSolidFireQoSPolicyExists(n string, policy *element.QoSPolicy) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		virConn := testAccProvider.Meta().(*element.Client)
+
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No SolidFire QoS policy key ID is set")
+		}
+
+		convID, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		retrievedPolicy, err := getQoSPolicy(virConn, convID)
+		if err != nil {
+			return err
+		}
+
+		if retrievedPolicy == nil {
+			return fmt.Errorf("QoS policy %v not found", convID)
+		}
+
+		*policy = *retrievedPolicy
+
+		return nil
+	}
+}
+
+const testAccCheckSolidFireQoSPolicyConfig = `
+resource "solidfire_qos_policy" "terraform-acceptance-test-1" {
+	name = "terraform-acceptance-test"
+	min_iops = "%s"
+	max_iops = "%s"
+	burst_iops = "%s"
+}
+resource "solidfire_volume" "terraform-acceptance-test-1" {
+	name = "terraform-acceptance-test-qos"
+	account_id = "${solidfire_account.terraform-acceptance-test-1.id}"
+	total_size = "1073741824"
+	enable512e = "true"
+	%s
+}
+resource "solidfire_account" "terraform-acceptance-test-1" {
+	username = "terraform-acceptance-test-qos"
+}
+`
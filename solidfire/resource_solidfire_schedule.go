@@ -0,0 +1,458 @@
+package solidfire
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element"
+)
+
+// scheduleFrequencies maps the frequency argument to the frequency attribute of an Element schedule
+var scheduleFrequencies = map[string]string{
+	"time-interval": "Time Interval",
+	"days-of-week":  "Days Of Week",
+	"days-of-month": "Days Of Month",
+}
+
+func resourceSolidFireSchedule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSolidFireScheduleCreate,
+		Read:   resourceSolidFireScheduleRead,
+		Update: resourceSolidFireScheduleUpdate,
+		Delete: resourceSolidFireScheduleDelete,
+		Exists: resourceSolidFireScheduleExists,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: resourceSolidFireScheduleCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"frequency": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"time-interval",
+					"days-of-week",
+					"days-of-month",
+				}, false),
+			},
+			"hours": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntBetween(0, 8760),
+			},
+			"minutes": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntBetween(0, 59),
+			},
+			"weekdays": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(0, 6),
+				},
+				Set: schema.HashInt,
+			},
+			"monthdays": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(1, 31),
+				},
+				Set: schema.HashInt,
+			},
+			"volume_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ConflictsWith: []string{"volume_ids"},
+			},
+			"volume_ids": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeInt},
+				Set:           schema.HashInt,
+				ConflictsWith: []string{"volume_id"},
+			},
+			"snapshot_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"retention": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRetention,
+			},
+			"enable_remote_replication": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"paused": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"recurring": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			// The cluster clears runNextInterval once the schedule has run, so it is not read back
+			"run_next_interval": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"starting_date": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateRFC3339,
+			},
+			"last_run_time_started": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_run_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"has_error": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSolidFireScheduleCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Creating schedule: %#v", d)
+	client := meta.(*element.Client)
+
+	schedule := element.CreateScheduleRequest{
+		ScheduleType:    "Snapshot",
+		ScheduleInfo:    expandScheduleInfo(d),
+		Attributes:      map[string]interface{}{"frequency": scheduleFrequencies[d.Get("frequency").(string)]},
+		Hours:           d.Get("hours").(int),
+		Minutes:         d.Get("minutes").(int),
+		Weekdays:        expandScheduleWeekdays(d),
+		Monthdays:       expandIntSet(d.Get("monthdays").(*schema.Set)),
+		Paused:          d.Get("paused").(bool),
+		Recurring:       d.Get("recurring").(bool),
+		RunNextInterval: d.Get("run_next_interval").(bool),
+	}
+
+	if v, ok := d.GetOk("name"); ok {
+		schedule.ScheduleName = v.(string)
+	} else {
+		return fmt.Errorf("name argument is required")
+	}
+
+	if v, ok := d.GetOk("starting_date"); ok {
+		schedule.StartingDate = v.(string)
+	}
+
+	log.Printf("Parameters: %v", element.Redact(schedule))
+
+	resp, err := client.CreateSchedule(schedule)
+	if err != nil {
+		log.Print("Error creating schedule")
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%v", resp.ScheduleID))
+	log.Printf("Created schedule: %v %v", schedule.ScheduleName, resp.ScheduleID)
+
+	return resourceSolidFireScheduleRead(d, meta)
+}
+
+func resourceSolidFireScheduleRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Reading schedule: %#v", d)
+	client := meta.(*element.Client)
+
+	id := d.Id()
+	convID, convErr := strconv.Atoi(id)
+
+	if convErr != nil {
+		return fmt.Errorf("id argument is required")
+	}
+
+	res, err := client.GetSchedule(element.GetScheduleRequest{ScheduleID: convID})
+	if err != nil {
+		if errors.Is(err, element.ErrNotFound) {
+			log.Printf("Schedule %v not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	schedule := res.Schedule
+
+	if schedule.ToBeDeleted {
+		log.Printf("Schedule %v is being deleted, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	attributes, _ := schedule.Attributes.(map[string]interface{})
+	frequency, _ := attributes["frequency"].(string)
+	for k, v := range scheduleFrequencies {
+		if v == frequency {
+			d.Set("frequency", k)
+		}
+	}
+
+	// The cluster ignores days that do not apply to the frequency, so only the days of the current one are read
+	weekdays := []int{}
+	monthdays := []int{}
+	switch frequency {
+	case scheduleFrequencies["days-of-week"]:
+		for _, weekday := range schedule.Weekdays {
+			weekdays = append(weekdays, weekday.Day)
+		}
+	case scheduleFrequencies["days-of-month"]:
+		monthdays = schedule.Monthdays
+	}
+
+	d.Set("name", schedule.ScheduleName)
+	d.Set("hours", schedule.Hours)
+	d.Set("minutes", schedule.Minutes)
+	d.Set("weekdays", weekdays)
+	d.Set("monthdays", monthdays)
+	d.Set("paused", schedule.Paused)
+	d.Set("recurring", schedule.Recurring)
+	d.Set("starting_date", schedule.StartingDate)
+	d.Set("last_run_time_started", schedule.LastRunTimeStarted)
+	d.Set("last_run_status", schedule.LastRunStatus)
+	d.Set("has_error", schedule.HasError)
+
+	info := schedule.ScheduleInfo
+	if len(info.Volumes) > 0 {
+		d.Set("volume_id", 0)
+		d.Set("volume_ids", info.Volumes)
+	} else {
+		d.Set("volume_id", info.VolumeID)
+		d.Set("volume_ids", []int{})
+	}
+	d.Set("snapshot_name", info.Name)
+	d.Set("retention", info.Retention)
+	d.Set("enable_remote_replication", info.EnableRemoteReplication)
+
+	return nil
+}
+
+func resourceSolidFireScheduleUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Updating schedule %#v", d)
+	client := meta.(*element.Client)
+
+	schedule := element.ModifyScheduleRequest{}
+	changed := false
+
+	id := d.Id()
+	convID, convErr := strconv.Atoi(id)
+
+	if convErr != nil {
+		return fmt.Errorf("id argument is required")
+	}
+	schedule.ScheduleID = convID
+
+	if d.HasChange("name") {
+		schedule.ScheduleName = d.Get("name").(string)
+		changed = true
+	}
+
+	// The frequency and the time fields are interpreted together, so they are sent together
+	if d.HasChange("frequency") || d.HasChange("hours") || d.HasChange("minutes") || d.HasChange("weekdays") || d.HasChange("monthdays") {
+		hours := d.Get("hours").(int)
+		minutes := d.Get("minutes").(int)
+		schedule.Attributes = map[string]interface{}{"frequency": scheduleFrequencies[d.Get("frequency").(string)]}
+		schedule.Hours = &hours
+		schedule.Minutes = &minutes
+		schedule.Weekdays = expandScheduleWeekdays(d)
+		schedule.Monthdays = expandIntSet(d.Get("monthdays").(*schema.Set))
+		changed = true
+	}
+
+	if d.HasChange("volume_id") || d.HasChange("volume_ids") || d.HasChange("snapshot_name") || d.HasChange("retention") || d.HasChange("enable_remote_replication") {
+		info := expandScheduleInfo(d)
+		schedule.ScheduleInfo = &info
+		changed = true
+	}
+
+	if d.HasChange("paused") {
+		paused := d.Get("paused").(bool)
+		schedule.Paused = &paused
+		changed = true
+	}
+
+	if d.HasChange("recurring") {
+		recurring := d.Get("recurring").(bool)
+		schedule.Recurring = &recurring
+		changed = true
+	}
+
+	if d.HasChange("run_next_interval") {
+		runNextInterval := d.Get("run_next_interval").(bool)
+		schedule.RunNextInterval = &runNextInterval
+		changed = true
+	}
+
+	if v, ok := d.GetOk("starting_date"); ok && d.HasChange("starting_date") {
+		schedule.StartingDate = v.(string)
+		changed = true
+	}
+
+	if changed {
+		log.Printf("Parameters: %v", element.Redact(schedule))
+
+		_, err := client.ModifySchedule(schedule)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceSolidFireScheduleRead(d, meta)
+}
+
+func resourceSolidFireScheduleDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Deleting schedule: %#v", d)
+	client := meta.(*element.Client)
+
+	id := d.Id()
+	convID, convErr := strconv.Atoi(id)
+
+	if convErr != nil {
+		return fmt.Errorf("id argument is required")
+	}
+
+	// Element has no method to delete a schedule; the cluster deletes schedules marked toBeDeleted
+	toBeDeleted := true
+	schedule := element.ModifyScheduleRequest{ScheduleID: convID, ToBeDeleted: &toBeDeleted}
+
+	log.Printf("Parameters: %v", element.Redact(schedule))
+
+	_, err := client.ModifySchedule(schedule)
+	if err != nil {
+		if errors.Is(err, element.ErrNotFound) {
+			log.Printf("Schedule %v has already been deleted", id)
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+func resourceSolidFireScheduleExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	log.Printf("Checking existence of schedule: %#v", d)
+	client := meta.(*element.Client)
+
+	id := d.Id()
+	convID, convErr := strconv.Atoi(id)
+
+	if convErr != nil {
+		return false, fmt.Errorf("id argument is required")
+	}
+
+	res, err := client.GetSchedule(element.GetScheduleRequest{ScheduleID: convID})
+	if err != nil {
+		if errors.Is(err, element.ErrNotFound) {
+			d.SetId("")
+			return false, nil
+		}
+		return false, err
+	}
+
+	if res.Schedule.ToBeDeleted {
+		d.SetId("")
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// resourceSolidFireScheduleCustomizeDiff checks the arguments that the chosen frequency and target need
+func resourceSolidFireScheduleCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	_, single := d.GetOk("volume_id")
+	_, group := d.GetOk("volume_ids")
+	if !single && !group && d.NewValueKnown("volume_id") && d.NewValueKnown("volume_ids") {
+		return fmt.Errorf("one of volume_id or volume_ids is required")
+	}
+
+	if !d.NewValueKnown("frequency") || !d.NewValueKnown("hours") || !d.NewValueKnown("minutes") {
+		return nil
+	}
+
+	hours := d.Get("hours").(int)
+	minutes := d.Get("minutes").(int)
+	_, weekdays := d.GetOk("weekdays")
+	_, monthdays := d.GetOk("monthdays")
+
+	switch frequency := d.Get("frequency").(string); frequency {
+	case "time-interval":
+		if hours == 0 && minutes == 0 {
+			return fmt.Errorf("hours or minutes is required when frequency is time-interval")
+		}
+		if weekdays || monthdays {
+			return fmt.Errorf("weekdays and monthdays cannot be set when frequency is time-interval")
+		}
+	case "days-of-week", "days-of-month":
+		if hours > 23 {
+			return fmt.Errorf("hours is the hour of the day when frequency is %v and must be between 0 and 23, got %v", frequency, hours)
+		}
+		if frequency == "days-of-week" && (!weekdays || monthdays) {
+			return fmt.Errorf("weekdays, and not monthdays, is required when frequency is days-of-week")
+		}
+		if frequency == "days-of-month" && (!monthdays || weekdays) {
+			return fmt.Errorf("monthdays, and not weekdays, is required when frequency is days-of-month")
+		}
+	}
+
+	return nil
+}
+
+// expandScheduleInfo returns the snapshot settings of the schedule. A single volume is snapshotted with
+// volumeID and a group of volumes with volumes.
+func expandScheduleInfo(d *schema.ResourceData) element.ScheduleInfo {
+	return element.ScheduleInfo{
+		VolumeID:                d.Get("volume_id").(int),
+		Volumes:                 expandIntSet(d.Get("volume_ids").(*schema.Set)),
+		Name:                    d.Get("snapshot_name").(string),
+		EnableRemoteReplication: d.Get("enable_remote_replication").(bool),
+		Retention:               d.Get("retention").(string),
+	}
+}
+
+// expandScheduleWeekdays returns the configured days of the week, where 0 is Sunday
+func expandScheduleWeekdays(d *schema.ResourceData) []element.ScheduleWeekday {
+	var weekdays []element.ScheduleWeekday
+	for _, day := range expandIntSet(d.Get("weekdays").(*schema.Set)) {
+		weekdays = append(weekdays, element.ScheduleWeekday{Day: day, Offset: 1})
+	}
+	return weekdays
+}
+
+// expandIntSet returns the integers in set in ascending order
+func expandIntSet(set *schema.Set) []int {
+	var values []int
+	for _, v := range set.List() {
+		values = append(values, v.(int))
+	}
+	sort.Ints(values)
+	return values
+}
@@ -0,0 +1,159 @@
+package solidfire
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element"
+)
+
+func TestSchedule_basic(t *testing.T) {
+	var schedule element.Schedule
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireScheduleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireScheduleConfig, `
+	frequency = "time-interval"
+	hours = 1
+	volume_id = "${solidfire_volume.terraform-acceptance-test-1.id}"
+	retention = "24:00:00"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireScheduleExists("solidfire_schedule.terraform-acceptance-test-1", &schedule),
+					testAccCheckSolidFireScheduleFrequency(&schedule, "Time Interval"),
+					resource.TestCheckResourceAttr("solidfire_schedule.terraform-acceptance-test-1", "name", "terraform-acceptance-test"),
+					resource.TestCheckResourceAttr("solidfire_schedule.terraform-acceptance-test-1", "hours", "1"),
+					resource.TestCheckResourceAttr("solidfire_schedule.terraform-acceptance-test-1", "recurring", "true"),
+					resource.TestCheckResourceAttr("solidfire_schedule.terraform-acceptance-test-1", "paused", "false"),
+					resource.TestCheckResourceAttrPair("solidfire_schedule.terraform-acceptance-test-1", "volume_id", "solidfire_volume.terraform-acceptance-test-1", "id"),
+					resource.TestCheckResourceAttrSet("solidfire_schedule.terraform-acceptance-test-1", "starting_date"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireScheduleConfig, `
+	frequency = "days-of-week"
+	hours = 2
+	minutes = 30
+	weekdays = [1, 3, 5]
+	volume_ids = ["${solidfire_volume.terraform-acceptance-test-1.id}", "${solidfire_volume.terraform-acceptance-test-2.id}"]
+	snapshot_name = "weekly"
+	enable_remote_replication = true
+	paused = true`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireScheduleExists("solidfire_schedule.terraform-acceptance-test-1", &schedule),
+					testAccCheckSolidFireScheduleFrequency(&schedule, "Days Of Week"),
+					resource.TestCheckResourceAttr("solidfire_schedule.terraform-acceptance-test-1", "weekdays.#", "3"),
+					resource.TestCheckResourceAttr("solidfire_schedule.terraform-acceptance-test-1", "volume_ids.#", "2"),
+					resource.TestCheckResourceAttr("solidfire_schedule.terraform-acceptance-test-1", "volume_id", "0"),
+					resource.TestCheckResourceAttr("solidfire_schedule.terraform-acceptance-test-1", "snapshot_name", "weekly"),
+					resource.TestCheckResourceAttr("solidfire_schedule.terraform-acceptance-test-1", "enable_remote_replication", "true"),
+					resource.TestCheckResourceAttr("solidfire_schedule.terraform-acceptance-test-1", "paused", "true"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireScheduleConfig, `
+	frequency = "days-of-month"
+	monthdays = [1, 15]
+	volume_id = "${solidfire_volume.terraform-acceptance-test-1.id}"
+	retention = "720:00:00"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireScheduleExists("solidfire_schedule.terraform-acceptance-test-1", &schedule),
+					testAccCheckSolidFireScheduleFrequency(&schedule, "Days Of Month"),
+					resource.TestCheckResourceAttr("solidfire_schedule.terraform-acceptance-test-1", "monthdays.#", "2"),
+					resource.TestCheckResourceAttr("solidfire_schedule.terraform-acceptance-test-1", "weekdays.#", "0"),
+					resource.TestCheckResourceAttr("solidfire_schedule.terraform-acceptance-test-1", "retention", "720:00:00"),
+				),
+			},
+			{
+				ResourceName:            "solidfire_schedule.terraform-acceptance-test-1",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"run_next_interval"},
+			},
+		},
+	})
+}
+
+func testAccCheckSolidFireScheduleDestroy(s *terraform.State) error {
+	virConn := testAccProvider.Meta().(*element.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "solidfire_schedule" {
+			continue
+		}
+
+		convID, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		res, err := virConn.GetSchedule(element.GetScheduleRequest{ScheduleID: convID})
+		if err != nil {
+			if errors.Is(err, element.ErrNotFound) {
+				continue
+			}
+			return err
+		}
+		if !res.Schedule.ToBeDeleted {
+			return fmt.Errorf("Error waiting for schedule (%s) to be destroyed", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckSolidFireScheduleExists(n string, schedule *element.Schedule) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		virConn := testAccProvider.Meta().(*element.Client)
+
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No SolidFire schedule key ID is set")
+		}
+
+		convID, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		res, err := virConn.GetSchedule(element.GetScheduleRequest{ScheduleID: convID})
+		if err != nil {
+			return err
+		}
+
+		if res.Schedule.ScheduleID != convID {
+			return fmt.Errorf("Resource ID and schedule ID do not match")
+		}
+
+		*schedule = res.Schedule
+
+		return nil
+	}
+}
+
+func testAccCheckSolidFireScheduleFrequency(schedule *element.Schedule, frequency string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		attributes, _ := schedule.Attributes.(map[string]interface{})
+		if attributes["frequency"] != frequency {
+			return fmt.Errorf("Expected frequency %q, got %v", frequency, attributes["frequency"])
+		}
+		return nil
+	}
+}
+
+const testAccCheckSolidFireScheduleConfig = `
+resource "solidfire_schedule" "terraform-acceptance-test-1" {
+	name = "terraform-acceptance-test"
+	%s
+}
+` + testAccCheckSolidFireGroupSnapshotVolumesConfig
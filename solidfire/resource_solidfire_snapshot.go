@@ -0,0 +1,346 @@
+package solidfire
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element"
+)
+
+func resourceSolidFireSnapshot() *schema.Resource {
+	attributes := attributesSchema()
+	attributes.ForceNew = true
+	attributesJSON := attributesJSONSchema()
+	attributesJSON.ForceNew = true
+
+	return &schema.Resource{
+		Create: resourceSolidFireSnapshotCreate,
+		Read:   resourceSolidFireSnapshotRead,
+		Update: resourceSolidFireSnapshotUpdate,
+		Delete: resourceSolidFireSnapshotDelete,
+		Exists: resourceSolidFireSnapshotExists,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: customizeDiffAll(
+			requireFeature(element.FeatureSnapMirror, "snap_mirror_label"),
+			customizeDiffRetentionRemoval,
+		),
+
+		Schema: map[string]*schema.Schema{
+			"volume_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"retention": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validateRetention,
+				ConflictsWith: []string{"expiration_time"},
+			},
+			"expiration_time": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validateRFC3339,
+				ConflictsWith: []string{"retention"},
+			},
+			"enable_remote_replication": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"snap_mirror_label": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// ModifySnapshot cannot change the attributes of a snapshot
+			"attributes":      attributes,
+			"attributes_json": attributesJSON,
+			"checksum": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"create_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"group_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"snapshot_uuid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"total_size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSolidFireSnapshotCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Creating snapshot: %#v", d)
+	client := meta.(*element.Client)
+
+	snapshot := element.CreateSnapshotRequest{}
+
+	if v, ok := d.GetOk("volume_id"); ok {
+		snapshot.VolumeID = v.(int)
+	} else {
+		return fmt.Errorf("volume_id argument is required")
+	}
+
+	if v, ok := d.GetOk("name"); ok {
+		snapshot.Name = v.(string)
+	}
+
+	if v, ok := d.GetOk("retention"); ok {
+		snapshot.Retention = v.(string)
+	}
+
+	if v, ok := d.GetOk("enable_remote_replication"); ok {
+		snapshot.EnableRemoteReplication = v.(bool)
+	}
+
+	if v, ok := d.GetOk("snap_mirror_label"); ok {
+		snapshot.SnapMirrorLabel = v.(string)
+	}
+
+	attributes, err := expandAttributes(d)
+	if err != nil {
+		return err
+	}
+	if len(attributes) > 0 {
+		snapshot.Attributes = attributes
+	}
+
+	log.Printf("Parameters: %v", element.Redact(snapshot))
+
+	resp, err := client.CreateSnapshot(snapshot)
+	if err != nil {
+		log.Print("Error creating snapshot")
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%v", resp.SnapshotID))
+	log.Printf("Created snapshot: %v %v", resp.Snapshot.Name, resp.SnapshotID)
+
+	// CreateSnapshot only accepts a retention period, so a fixed expiration time is set afterwards
+	if v, ok := d.GetOk("expiration_time"); ok {
+		modify := element.ModifySnapshotRequest{SnapshotID: resp.SnapshotID, ExpirationTime: v.(string)}
+
+		log.Printf("Parameters: %v", element.Redact(modify))
+
+		if _, err := client.ModifySnapshot(modify); err != nil {
+			return err
+		}
+	}
+
+	return resourceSolidFireSnapshotRead(d, meta)
+}
+
+func resourceSolidFireSnapshotRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Reading snapshot: %#v", d)
+	client := meta.(*element.Client)
+
+	id := d.Id()
+	convID, convErr := strconv.Atoi(id)
+
+	if convErr != nil {
+		return fmt.Errorf("id argument is required")
+	}
+
+	res, err := client.ListSnapshots(element.ListSnapshotsRequest{SnapshotID: convID})
+	if err != nil {
+		if errors.Is(err, element.ErrNotFound) {
+			log.Printf("Snapshot %v not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	if len(res.Snapshots) == 0 {
+		log.Printf("Snapshot %v not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	if len(res.Snapshots) != 1 {
+		return fmt.Errorf("Expected one Snapshot to be found. Response contained %v results", len(res.Snapshots))
+	}
+
+	snapshot := res.Snapshots[0]
+
+	d.Set("volume_id", snapshot.VolumeID)
+	d.Set("name", snapshot.Name)
+	d.Set("expiration_time", snapshot.ExpirationTime)
+	d.Set("enable_remote_replication", snapshot.EnableRemoteReplication)
+	d.Set("snap_mirror_label", snapshot.SnapMirrorLabel)
+	d.Set("checksum", snapshot.Checksum)
+	d.Set("create_time", snapshot.CreateTime)
+	d.Set("group_id", snapshot.GroupID)
+	d.Set("status", snapshot.Status)
+	d.Set("snapshot_uuid", snapshot.SnapshotUUID)
+	d.Set("total_size", snapshot.TotalSize)
+
+	if err := setAttributes(d, snapshot.Attributes); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceSolidFireSnapshotUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Updating snapshot %#v", d)
+	client := meta.(*element.Client)
+
+	snapshot := element.ModifySnapshotRequest{}
+	changed := false
+
+	id := d.Id()
+	convID, convErr := strconv.Atoi(id)
+
+	if convErr != nil {
+		return fmt.Errorf("id argument is required")
+	}
+	snapshot.SnapshotID = convID
+
+	if d.HasChange("name") {
+		snapshot.Name = d.Get("name").(string)
+		changed = true
+	}
+
+	if d.HasChange("enable_remote_replication") {
+		enable := d.Get("enable_remote_replication").(bool)
+		snapshot.EnableRemoteReplication = &enable
+		changed = true
+	}
+
+	if d.HasChange("snap_mirror_label") {
+		snapshot.SnapMirrorLabel = d.Get("snap_mirror_label").(string)
+		changed = true
+	}
+
+	if v, ok := d.GetOk("retention"); ok && d.HasChange("retention") {
+		expiration, err := retentionExpirationTime(d.Get("create_time").(string), v.(string))
+		if err != nil {
+			return fmt.Errorf("Unable to set retention of snapshot %v: %v", id, err)
+		}
+		snapshot.ExpirationTime = expiration
+		changed = true
+	}
+
+	if v, ok := d.GetOk("expiration_time"); ok && d.HasChange("expiration_time") {
+		snapshot.ExpirationTime = v.(string)
+		changed = true
+	}
+
+	if changed {
+		log.Printf("Parameters: %v", element.Redact(snapshot))
+
+		_, err := client.ModifySnapshot(snapshot)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceSolidFireSnapshotRead(d, meta)
+}
+
+// customizeDiffRetentionRemoval rejects plans that remove retention from an existing snapshot without setting
+// expiration_time. ModifySnapshot cannot clear an expiration, so the snapshot would silently keep it.
+func customizeDiffRetentionRemoval(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("retention") || !d.NewValueKnown("retention") {
+		return nil
+	}
+
+	if d.Get("retention").(string) == "" && !d.HasChange("expiration_time") {
+		return fmt.Errorf("retention cannot be removed because the cluster cannot clear the expiration time of a " +
+			"snapshot. Set expiration_time instead, or recreate the snapshot to keep it until it is deleted")
+	}
+
+	return nil
+}
+
+func resourceSolidFireSnapshotDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Deleting snapshot: %#v", d)
+	client := meta.(*element.Client)
+
+	id := d.Id()
+	convID, convErr := strconv.Atoi(id)
+
+	if convErr != nil {
+		return fmt.Errorf("id argument is required")
+	}
+
+	_, err := client.DeleteSnapshot(element.DeleteSnapshotRequest{SnapshotID: convID})
+	if err != nil {
+		if errors.Is(err, element.ErrNotFound) {
+			log.Printf("Snapshot %v has already expired or been deleted", id)
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+func resourceSolidFireSnapshotExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	log.Printf("Checking existence of snapshot: %#v", d)
+	client := meta.(*element.Client)
+
+	id := d.Id()
+	convID, convErr := strconv.Atoi(id)
+
+	if convErr != nil {
+		return false, fmt.Errorf("id argument is required")
+	}
+
+	res, err := client.ListSnapshots(element.ListSnapshotsRequest{SnapshotID: convID})
+	if err != nil {
+		if errors.Is(err, element.ErrNotFound) {
+			d.SetId("")
+			return false, nil
+		}
+		return false, err
+	}
+
+	if len(res.Snapshots) != 1 {
+		d.SetId("")
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// retentionExpirationTime returns the expiration time of a snapshot taken at createTime that is kept for
+// retention. A new retention period counts from the time the snapshot was taken, not from the update.
+func retentionExpirationTime(createTime string, retention string) (string, error) {
+	period, err := parseRetention(retention)
+	if err != nil {
+		return "", err
+	}
+	created, err := time.Parse(time.RFC3339, createTime)
+	if err != nil {
+		return "", fmt.Errorf("Unable to parse create time %q: %v", createTime, err)
+	}
+	return created.Add(period).UTC().Format(time.RFC3339), nil
+}
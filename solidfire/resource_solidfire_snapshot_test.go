@@ -0,0 +1,181 @@
+package solidfire
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element"
+)
+
+func TestSnapshot_basic(t *testing.T) {
+	var snapshot element.Snapshot
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireSnapshotDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireSnapshotConfig, `
+	name = "terraform-acceptance-test"
+	retention = "24:00:00"
+	attributes = {
+		job = "nightly"
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireSnapshotExists("solidfire_snapshot.terraform-acceptance-test-1", &snapshot),
+					testAccCheckSolidFireSnapshotRetention(&snapshot, 24*time.Hour),
+					resource.TestCheckResourceAttr("solidfire_snapshot.terraform-acceptance-test-1", "name", "terraform-acceptance-test"),
+					resource.TestCheckResourceAttr("solidfire_snapshot.terraform-acceptance-test-1", "attributes.job", "nightly"),
+					resource.TestCheckResourceAttr("solidfire_snapshot.terraform-acceptance-test-1", "group_id", "0"),
+					resource.TestCheckResourceAttrPair("solidfire_snapshot.terraform-acceptance-test-1", "volume_id", "solidfire_volume.terraform-acceptance-test-1", "id"),
+					resource.TestCheckResourceAttrSet("solidfire_snapshot.terraform-acceptance-test-1", "checksum"),
+					resource.TestCheckResourceAttrSet("solidfire_snapshot.terraform-acceptance-test-1", "create_time"),
+					resource.TestCheckResourceAttrSet("solidfire_snapshot.terraform-acceptance-test-1", "expiration_time"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireSnapshotConfig, `
+	name = "terraform-acceptance-test-update"
+	retention = "72:00:00"
+	enable_remote_replication = true
+	attributes = {
+		job = "nightly"
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireSnapshotExists("solidfire_snapshot.terraform-acceptance-test-1", &snapshot),
+					testAccCheckSolidFireSnapshotRetention(&snapshot, 72*time.Hour),
+					resource.TestCheckResourceAttr("solidfire_snapshot.terraform-acceptance-test-1", "name", "terraform-acceptance-test-update"),
+					resource.TestCheckResourceAttr("solidfire_snapshot.terraform-acceptance-test-1", "enable_remote_replication", "true"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireSnapshotConfig, `
+	name = "terraform-acceptance-test-update"
+	enable_remote_replication = true
+	attributes = {
+		job = "nightly"
+	}`),
+				ExpectError: regexp.MustCompile("retention cannot be removed"),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireSnapshotConfig, `
+	name = "terraform-acceptance-test-update"
+	expiration_time = "2099-01-01T00:00:00Z"
+	attributes = {
+		job = "nightly"
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireSnapshotExists("solidfire_snapshot.terraform-acceptance-test-1", &snapshot),
+					resource.TestCheckResourceAttr("solidfire_snapshot.terraform-acceptance-test-1", "expiration_time", "2099-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("solidfire_snapshot.terraform-acceptance-test-1", "enable_remote_replication", "false"),
+				),
+			},
+			{
+				ResourceName:            "solidfire_snapshot.terraform-acceptance-test-1",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"retention"},
+			},
+			{
+				Config:      fmt.Sprintf(testAccCheckSolidFireSnapshotConfig, `retention = "1 day"`),
+				ExpectError: regexp.MustCompile("HH:mm:ss"),
+			},
+		},
+	})
+}
+
+func testAccCheckSolidFireSnapshotDestroy(s *terraform.State) error {
+	virConn := testAccProvider.Meta().(*element.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "solidfire_snapshot" {
+			continue
+		}
+
+		convID, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		res, err := virConn.ListSnapshots(element.ListSnapshotsRequest{SnapshotID: convID})
+		if err == nil && len(res.Snapshots) > 0 {
+			return fmt.Errorf("Error waiting for snapshot (%s) to be destroyed", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckSolidFireSnapshotExists(n string, snapshot *element.Snapshot) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		virConn := testAccProvider.Meta().(*element.Client)
+
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No SolidFire snapshot key ID is set")
+		}
+
+		convID, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		res, err := virConn.ListSnapshots(element.ListSnapshotsRequest{SnapshotID: convID})
+		if err != nil {
+			return err
+		}
+
+		if len(res.Snapshots) != 1 || res.Snapshots[0].SnapshotID != convID {
+			return fmt.Errorf("Resource ID and snapshot ID do not match")
+		}
+
+		*snapshot = res.Snapshots[0]
+
+		return nil
+	}
+}
+
+// testAccCheckSolidFireSnapshotRetention checks that snapshot expires retention after it was taken
+func testAccCheckSolidFireSnapshotRetention(snapshot *element.Snapshot, retention time.Duration) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		created, err := time.Parse(time.RFC3339, snapshot.CreateTime)
+		if err != nil {
+			return err
+		}
+		expires, err := time.Parse(time.RFC3339, snapshot.ExpirationTime)
+		if err != nil {
+			return err
+		}
+
+		if actual := expires.Sub(created); actual < retention-time.Minute || actual > retention+time.Minute {
+			return fmt.Errorf("Expected a retention of %v, got %v", retention, actual)
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSolidFireSnapshotConfig = `
+resource "solidfire_snapshot" "terraform-acceptance-test-1" {
+	volume_id = "${solidfire_volume.terraform-acceptance-test-1.id}"
+	%s
+}
+resource "solidfire_volume" "terraform-acceptance-test-1" {
+	name = "terraform-acceptance-test-snapshot"
+	account_id = "${solidfire_account.terraform-acceptance-test-1.id}"
+	total_size = "1073741824"
+	enable512e = "true"
+}
+resource "solidfire_account" "terraform-acceptance-test-1" {
+	username = "terraform-acceptance-test-snapshot"
+}
+`
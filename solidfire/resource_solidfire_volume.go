@@ -1,50 +1,18 @@
 package solidfire
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
+	"time"
 
-	"encoding/json"
-
-	"github.com/fatih/structs"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/solidfire/terraform-provider-solidfire/solidfire/element"
-	"github.com/solidfire/terraform-provider-solidfire/solidfire/element/jsonrpc"
 )
 
-type CreateVolumeRequest struct {
-	Name       string           `structs:"name"`
-	AccountID  int              `structs:"accountID"`
-	TotalSize  int              `structs:"totalSize"`
-	Enable512E bool             `structs:"enable512e"`
-	Attributes interface{}      `structs:"attributes"`
-	QOS        QualityOfService `structs:"qos"`
-}
-
-type CreateVolumeResult struct {
-	VolumeID int            `json:"volumeID"`
-	Volume   element.Volume `json:"volume"`
-}
-
-type DeleteVolumeRequest struct {
-	VolumeID int `structs:"volumeID"`
-}
-
-type ModifyVolumeRequest struct {
-	VolumeID   int              `structs:"volumeID"`
-	AccountID  int              `structs:"accountID"`
-	Attributes interface{}      `structs:"attributes"`
-	QOS        QualityOfService `structs:"qos"`
-	TotalSize  int              `structs:"totalSize"`
-}
-
-type QualityOfService struct {
-	MinIOPS   int `structs:"minIOPS"`
-	MaxIOPS   int `structs:"maxIOPS"`
-	BurstIOPS int `structs:"burstIOPS"`
-}
-
 func resourceSolidFireVolume() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceSolidFireVolumeCreate,
@@ -55,47 +23,167 @@ func resourceSolidFireVolume() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		SchemaVersion: attributesSchemaVersion,
+		MigrateState:  migrateAttributesState,
+		CustomizeDiff: customizeDiffAll(
+			resourceSolidFireVolumeCustomizeDiff,
+			customizeDiffQoS,
+			requireFeature(element.FeatureQoSPolicies, "qos_policy_id"),
+			requireFeature(element.FeatureFifoSnapshots, "fifo_size", "min_fifo_size"),
+		),
 
 		Schema: map[string]*schema.Schema{
+			// ModifyVolume cannot rename a volume or change its sector emulation
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"account_id": {
 				Type:     schema.TypeInt,
 				Required: true,
 			},
 			"total_size": {
-				Type:     schema.TypeInt,
-				Required: true,
+				Type:             schema.TypeInt,
+				Required:         true,
+				DiffSuppressFunc: suppressEquivalentVolumeSize,
 			},
 			"enable512e": {
 				Type:     schema.TypeBool,
 				Required: true,
+				ForceNew: true,
 			},
 			"min_iops": {
-				Type:     schema.TypeInt,
-				Optional: true,
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"qos_policy_id"},
 			},
 			"max_iops": {
-				Type:     schema.TypeInt,
-				Optional: true,
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"qos_policy_id"},
 			},
 			"burst_iops": {
-				Type:     schema.TypeInt,
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"qos_policy_id"},
+			},
+			"qos_policy_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ConflictsWith: []string{"min_iops", "max_iops", "burst_iops"},
+			},
+			"access": {
+				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"readWrite",
+					"readOnly",
+					"locked",
+					"replicationTarget",
+				}, false),
 			},
-			"attributes": {
-				Type:     schema.TypeList,
+			"fifo_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(1, 32),
+			},
+			"min_fifo_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(0, 32),
+			},
+			"attributes":      attributesSchema(),
+			"attributes_json": attributesJSONSchema(),
+			"delete_behavior": {
+				Type:     schema.TypeString,
 				Optional: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
+				Default:  "purge",
+				ValidateFunc: validation.StringInSlice([]string{
+					"purge",
+					"soft-delete",
+					"soft-delete-with-retention",
+				}, false),
+			},
+			"delete_retention": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDuration,
+			},
+			"restore_deleted": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
 			},
 			"iqn": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"scsi_naa_device_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"scsi_eui_device_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"block_size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"create_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"volume_access_groups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"volume_pairs": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cluster_pair_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"remote_volume_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"remote_volume_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"volume_pair_uuid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"mode": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -104,7 +192,8 @@ func resourceSolidFireVolumeCreate(d *schema.ResourceData, meta interface{}) err
 	log.Printf("Creating volume: %#v", d)
 	client := meta.(*element.Client)
 
-	volume := CreateVolumeRequest{}
+	volume := element.CreateVolumeRequest{}
+	qos := element.QoS{}
 
 	if v, ok := d.GetOk("name"); ok {
 		volume.Name = v.(string)
@@ -124,25 +213,62 @@ func resourceSolidFireVolumeCreate(d *schema.ResourceData, meta interface{}) err
 		return fmt.Errorf("total_size argument is required")
 	}
 
-	if v, ok := d.GetOk("enable512e"); ok {
-		volume.Enable512E = v.(bool)
-	} else {
-		return fmt.Errorf("enable512e argument is required")
-	}
+	// enable512e is required, and GetOk would treat false as unset
+	volume.Enable512e = d.Get("enable512e").(bool)
 
 	if v, ok := d.GetOk("min_iops"); ok {
-		volume.QOS.MinIOPS = v.(int)
+		qos.MinIOPS = v.(int)
 	}
 
 	if v, ok := d.GetOk("max_iops"); ok {
-		volume.QOS.MaxIOPS = v.(int)
+		qos.MaxIOPS = v.(int)
 	}
 
 	if v, ok := d.GetOk("burst_iops"); ok {
-		volume.QOS.BurstIOPS = v.(int)
+		qos.BurstIOPS = v.(int)
+	}
+
+	if qos != (element.QoS{}) {
+		volume.QoS = &qos
+	}
+
+	if v, ok := d.GetOk("qos_policy_id"); ok {
+		volume.QoSPolicyID = v.(int)
 	}
 
-	resp, err := createVolume(client, volume)
+	if v, ok := d.GetOk("fifo_size"); ok {
+		volume.FifoSize = v.(int)
+	}
+
+	// GetOkExists, because reserving no FIFO snapshot slots with 0 is a valid setting
+	if v, ok := d.GetOkExists("min_fifo_size"); ok {
+		minFifoSize := v.(int)
+		volume.MinFifoSize = &minFifoSize
+	}
+
+	attributes, err := expandAttributes(d)
+	if err != nil {
+		return err
+	}
+	if len(attributes) > 0 {
+		volume.Attributes = attributes
+	}
+
+	if d.Get("restore_deleted").(bool) {
+		restored, err := restoreDeletedVolume(client, volume, d.Get("access").(string))
+		if err != nil {
+			return err
+		}
+		if restored != 0 {
+			d.SetId(strconv.Itoa(restored))
+			log.Printf("Restored deleted volume: %v %v", volume.Name, restored)
+			return resourceSolidFireVolumeRead(d, meta)
+		}
+	}
+
+	log.Printf("Parameters: %v", element.Redact(volume))
+
+	resp, err := client.CreateVolume(volume)
 	if err != nil {
 		log.Print("Error creating volume")
 		return err
@@ -152,82 +278,143 @@ func resourceSolidFireVolumeCreate(d *schema.ResourceData, meta interface{}) err
 	d.Set("iqn", resp.Volume.Iqn)
 	log.Printf("Created volume: %v %v", volume.Name, resp.VolumeID)
 
-	return resourceSolidFireVolumeRead(d, meta)
-}
-
-func createVolume(client *element.Client, request CreateVolumeRequest) (CreateVolumeResult, error) {
-	params := structs.Map(request)
+	// CreateVolume only accepts access from recent Element releases, so it is set afterwards at every API level
+	if v, ok := d.GetOk("access"); ok && v.(string) != resp.Volume.Access {
+		modify := element.ModifyVolumeRequest{VolumeID: resp.VolumeID, Access: v.(string)}
 
-	log.Printf("Parameters: %v", params)
+		log.Printf("Parameters: %v", element.Redact(modify))
 
-	response, err := client.CallAPIMethod("CreateVolume", params)
-	if err != nil {
-		log.Print("CreateVolume request failed")
-		return CreateVolumeResult{}, err
+		if _, err := client.ModifyVolume(modify); err != nil {
+			return err
+		}
 	}
 
-	var result CreateVolumeResult
-	if err := json.Unmarshal([]byte(*response), &result); err != nil {
-		log.Print("Failed to unmarshall response from CreateVolume")
-		return CreateVolumeResult{}, err
-	}
-	return result, nil
+	return resourceSolidFireVolumeRead(d, meta)
 }
 
 func resourceSolidFireVolumeRead(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("Reading volume: %#v", d)
 	client := meta.(*element.Client)
 
-	volumes := element.ListVolumesRequest{}
-
 	id := d.Id()
-	s := make([]int, 1)
 	convID, convErr := strconv.Atoi(id)
 
 	if convErr != nil {
 		return fmt.Errorf("id argument is required")
 	}
 
-	s[0] = convID
-	volumes.Volumes = s
-
-	res, err := listVolumes(client, volumes)
+	res, err := client.ListVolumes(element.ListVolumesRequest{VolumeIDs: []int{convID}})
 	if err != nil {
+		if errors.Is(err, element.ErrNotFound) {
+			log.Printf("Volume %v not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 
+	if len(res.Volumes) == 0 {
+		log.Printf("Volume %v not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
 	if len(res.Volumes) != 1 {
 		return fmt.Errorf("Expected one Volume to be found. Response contained %v results", len(res.Volumes))
 	}
 
-	d.Set("name", res.Volumes[0].Name)
+	volume := res.Volumes[0]
+
+	if volume.Status == "deleted" {
+		log.Printf("Volume %v is deleted, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", volume.Name)
+	d.Set("account_id", volume.AccountID)
+	d.Set("enable512e", volume.Enable512e)
+	d.Set("min_iops", volume.QoS.MinIOPS)
+	d.Set("max_iops", volume.QoS.MaxIOPS)
+	d.Set("burst_iops", volume.QoS.BurstIOPS)
+	if volume.QoSPolicyID != nil {
+		d.Set("qos_policy_id", *volume.QoSPolicyID)
+	} else {
+		d.Set("qos_policy_id", 0)
+	}
+	d.Set("iqn", volume.Iqn)
+	d.Set("access", volume.Access)
+	d.Set("status", volume.Status)
+	d.Set("scsi_naa_device_id", volume.ScsiNAADeviceID)
+	d.Set("scsi_eui_device_id", volume.ScsiEUIDeviceID)
+	d.Set("block_size", volume.BlockSize)
+	d.Set("create_time", volume.CreateTime)
+	d.Set("fifo_size", volume.FifoSize)
+	d.Set("min_fifo_size", volume.MinFifoSize)
+
+	// The cluster rounds the requested size up to a whole MiB. Keep the configured size while it still
+	// rounds to the size of the volume, so that only real changes show up as drift.
+	if roundVolumeSize(d.Get("total_size").(int)) != volume.TotalSize {
+		d.Set("total_size", volume.TotalSize)
+	}
+
+	if err := d.Set("volume_access_groups", volume.VolumeAccessGroups); err != nil {
+		return fmt.Errorf("Error setting volume_access_groups: %v", err)
+	}
+
+	if err := d.Set("volume_pairs", flattenVolumePairs(volume.VolumePairs)); err != nil {
+		return fmt.Errorf("Error setting volume_pairs: %v", err)
+	}
+
+	if err := setAttributes(d, volume.Attributes); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func listVolumes(client *element.Client, request element.ListVolumesRequest) (element.ListVolumesResult, error) {
-	params := structs.Map(request)
+// roundVolumeSize returns the size in bytes that the cluster allocates for a volume of size bytes
+func roundVolumeSize(size int) int {
+	const mebibyte = 1024 * 1024
+	return (size + mebibyte - 1) / mebibyte * mebibyte
+}
 
-	response, err := client.CallAPIMethod("ListVolumes", params)
+// suppressEquivalentVolumeSize suppresses diffs between sizes that the cluster rounds to the same size, such as
+// the configured size and the rounded size stored by an import
+func suppressEquivalentVolumeSize(k, old, new string, d *schema.ResourceData) bool {
+	o, err := strconv.Atoi(old)
 	if err != nil {
-		log.Print("ListVolumes request failed")
-		return element.ListVolumesResult{}, err
+		return false
 	}
-
-	var result element.ListVolumesResult
-	if err := json.Unmarshal([]byte(*response), &result); err != nil {
-		log.Print("Failed to unmarshall response from ListVolumes")
-		return element.ListVolumesResult{}, err
+	n, err := strconv.Atoi(new)
+	if err != nil {
+		return false
 	}
+	return roundVolumeSize(o) == roundVolumeSize(n)
+}
 
-	return result, nil
+func flattenVolumePairs(pairs []element.VolumePair) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(pairs))
+	for _, pair := range pairs {
+		result = append(result, map[string]interface{}{
+			"cluster_pair_id":    pair.ClusterPairID,
+			"remote_volume_id":   pair.RemoteVolumeID,
+			"remote_volume_name": pair.RemoteVolumeName,
+			"volume_pair_uuid":   pair.VolumePairUUID,
+			"mode":               pair.RemoteReplication.Mode,
+			"state":              pair.RemoteReplication.State,
+		})
+	}
+	return result
 }
 
 func resourceSolidFireVolumeUpdate(d *schema.ResourceData, meta interface{}) error {
-	log.Printf("Updating volume access group %#v", d)
+	log.Printf("Updating volume %#v", d)
 	client := meta.(*element.Client)
 
-	volume := ModifyVolumeRequest{}
+	volume := element.ModifyVolumeRequest{}
+	qos := element.QoS{}
+	changed := false
 
 	id := d.Id()
 	convID, convErr := strconv.Atoi(id)
@@ -237,46 +424,144 @@ func resourceSolidFireVolumeUpdate(d *schema.ResourceData, meta interface{}) err
 	}
 	volume.VolumeID = convID
 
-	err := updateVolume(client, volume)
-	if err != nil {
-		return err
+	if d.HasChange("account_id") {
+		volume.AccountID = d.Get("account_id").(int)
+		changed = true
 	}
 
-	return nil
-}
+	if d.HasChange("total_size") {
+		volume.TotalSize = d.Get("total_size").(int)
+		changed = true
+	}
+
+	if d.HasChange("fifo_size") {
+		volume.FifoSize = d.Get("fifo_size").(int)
+		changed = true
+	}
+
+	if d.HasChange("min_fifo_size") {
+		minFifoSize := d.Get("min_fifo_size").(int)
+		volume.MinFifoSize = &minFifoSize
+		changed = true
+	}
 
-func updateVolume(client *element.Client, request ModifyVolumeRequest) error {
-	params := structs.Map(request)
+	if attributesChanged(d) {
+		attributes, err := expandAttributes(d)
+		if err != nil {
+			return err
+		}
+		volume.Attributes = attributes
+		changed = true
+	}
+
+	if d.HasChange("access") {
+		volume.Access = d.Get("access").(string)
+		if volume.Access == "locked" {
+			warnActiveISCSISessions(client, convID)
+		}
+		changed = true
+	}
 
-	_, err := client.CallAPIMethod("ModifyVolume", params)
+	policyID := d.Get("qos_policy_id").(int)
+	if d.HasChange("qos_policy_id") {
+		if policyID != 0 {
+			volume.QoSPolicyID = policyID
+		} else {
+			// Detaching from the policy keeps its limits unless inline QoS is configured
+			associate := false
+			volume.AssociateWithQoSPolicy = &associate
+			qos = element.QoS{
+				MinIOPS:   d.Get("min_iops").(int),
+				MaxIOPS:   d.Get("max_iops").(int),
+				BurstIOPS: d.Get("burst_iops").(int),
+			}
+		}
+		changed = true
+	}
+
+	// While the volume is associated with a policy its limits are managed by the policy
+	if policyID == 0 {
+		if d.HasChange("min_iops") {
+			qos.MinIOPS = d.Get("min_iops").(int)
+		}
+
+		if d.HasChange("max_iops") {
+			qos.MaxIOPS = d.Get("max_iops").(int)
+		}
+
+		if d.HasChange("burst_iops") {
+			qos.BurstIOPS = d.Get("burst_iops").(int)
+		}
+	}
+
+	if qos != (element.QoS{}) {
+		volume.QoS = &qos
+		changed = true
+	}
+
+	if changed {
+		log.Printf("Parameters: %v", element.Redact(volume))
+
+		_, err := client.ModifyVolume(volume)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceSolidFireVolumeRead(d, meta)
+}
+
+// warnActiveISCSISessions logs a warning if initiators are connected to the volume, because locking it
+// fails their I/O. Failing to list the sessions does not prevent the change.
+func warnActiveISCSISessions(client *element.Client, volumeID int) {
+	res, err := client.ListISCSISessions(element.ListISCSISessionsRequest{})
 	if err != nil {
-		log.Print("ModifyVolume request failed")
-		return err
+		log.Printf("[WARN] Unable to list iSCSI sessions of volume %v: %v", volumeID, err)
+		return
 	}
 
-	return nil
+	var initiators []string
+	for _, session := range res.Sessions {
+		if session.VolumeID == volumeID {
+			initiators = append(initiators, session.InitiatorName)
+		}
+	}
+
+	if len(initiators) > 0 {
+		log.Printf("[WARN] Locking volume %v with %v active iSCSI sessions, I/O from %v will fail",
+			volumeID, len(initiators), strings.Join(initiators, ", "))
+	}
 }
 
 func resourceSolidFireVolumeDelete(d *schema.ResourceData, meta interface{}) error {
-	log.Printf("Deleting volume access group: %#v", d)
+	log.Printf("Deleting volume: %#v", d)
 	client := meta.(*element.Client)
 
-	volume := DeleteVolumeRequest{}
-
 	id := d.Id()
 	convID, convErr := strconv.Atoi(id)
 
 	if convErr != nil {
 		return fmt.Errorf("id argument is required")
 	}
-	volume.VolumeID = convID
 
-	deleteErr := deleteVolume(client, volume)
+	_, deleteErr := client.DeleteVolume(element.DeleteVolumeRequest{VolumeID: convID})
 	if deleteErr != nil {
 		return deleteErr
 	}
 
-	purgeErr := purgeDeletedVolume(client, volume)
+	switch d.Get("delete_behavior").(string) {
+	case "soft-delete":
+		log.Printf("Volume %v is deleted and can be restored until the cluster purges it", id)
+		return nil
+	case "soft-delete-with-retention":
+		retention, err := time.ParseDuration(d.Get("delete_retention").(string))
+		if err != nil {
+			return fmt.Errorf("Invalid delete_retention: %v", err)
+		}
+		return checkDeletedVolumeRetention(client, convID, retention)
+	}
+
+	_, purgeErr := client.PurgeDeletedVolume(element.PurgeDeletedVolumeRequest{VolumeID: convID})
 	if purgeErr != nil {
 		return purgeErr
 	}
@@ -284,27 +569,124 @@ func resourceSolidFireVolumeDelete(d *schema.ResourceData, meta interface{}) err
 	return nil
 }
 
-func deleteVolume(client *element.Client, request DeleteVolumeRequest) error {
-	params := structs.Map(request)
-
-	_, err := client.CallAPIMethod("DeleteVolume", params)
+// restoreDeletedVolume restores the most recently deleted volume that can stand in for the volume described
+// by req and returns its ID, or 0 if there is none. A deleted volume matches if it has the same name, account
+// and 512e setting and is not larger than the requested size. The QoS, FIFO and attribute settings of req and
+// access are applied to it and it is grown to the requested size.
+func restoreDeletedVolume(client *element.Client, req element.CreateVolumeRequest, access string) (int, error) {
+	res, err := client.ListDeletedVolumes(element.ListDeletedVolumesRequest{})
 	if err != nil {
-		log.Print("DeleteVolume request failed")
-		return err
+		return 0, err
 	}
 
-	return nil
+	var match *element.Volume
+	for i, volume := range res.Volumes {
+		if volume.Name != req.Name || volume.AccountID != req.AccountID {
+			continue
+		}
+		if volume.Enable512e != req.Enable512e || volume.TotalSize > roundVolumeSize(req.TotalSize) {
+			log.Printf("Deleted volume %v does not match the configuration and is not restored", volume.VolumeID)
+			continue
+		}
+		if match == nil || deletedAfter(volume, *match) {
+			match = &res.Volumes[i]
+		}
+	}
+
+	if match == nil {
+		return 0, nil
+	}
+
+	if _, err := client.RestoreDeletedVolume(element.RestoreDeletedVolumeRequest{VolumeID: match.VolumeID}); err != nil {
+		return 0, err
+	}
+
+	modify := element.ModifyVolumeRequest{
+		VolumeID:    match.VolumeID,
+		Access:      access,
+		QoS:         req.QoS,
+		QoSPolicyID: req.QoSPolicyID,
+		FifoSize:    req.FifoSize,
+		MinFifoSize: req.MinFifoSize,
+		Attributes:  req.Attributes,
+	}
+	if roundVolumeSize(req.TotalSize) > match.TotalSize {
+		modify.TotalSize = req.TotalSize
+	}
+
+	log.Printf("Parameters: %v", element.Redact(modify))
+
+	if _, err := client.ModifyVolume(modify); err != nil {
+		return 0, fmt.Errorf("Unable to apply the configuration to restored volume %v: %v", match.VolumeID, err)
+	}
+
+	return match.VolumeID, nil
 }
 
-func purgeDeletedVolume(client *element.Client, request DeleteVolumeRequest) error {
-	params := structs.Map(request)
+// deletedAfter reports whether deleted volume a was deleted after b. Delete times only have second precision,
+// so ties are broken by volume ID.
+func deletedAfter(a, b element.Volume) bool {
+	aTime, aErr := time.Parse(time.RFC3339, a.DeleteTime)
+	bTime, bErr := time.Parse(time.RFC3339, b.DeleteTime)
+	if aErr == nil && bErr == nil && !aTime.Equal(bTime) {
+		return aTime.After(bTime)
+	}
+	return a.VolumeID > b.VolumeID
+}
 
-	_, err := client.CallAPIMethod("PurgeDeletedVolume", params)
+// checkDeletedVolumeRetention makes sure a deleted volume stays recoverable for at least retention. If the
+// cluster would purge it sooner the volume is restored and an error is returned, so the volume is kept.
+func checkDeletedVolumeRetention(client *element.Client, volumeID int, retention time.Duration) error {
+	res, err := client.ListDeletedVolumes(element.ListDeletedVolumesRequest{})
 	if err != nil {
-		log.Print("PurgeDeletedVolume request failed")
 		return err
 	}
 
+	for _, volume := range res.Volumes {
+		if volume.VolumeID != volumeID {
+			continue
+		}
+
+		purgeTime, err := time.Parse(time.RFC3339, volume.PurgeTime)
+		if err != nil {
+			return fmt.Errorf("Unable to parse purge time %q of deleted volume %v: %v", volume.PurgeTime, volumeID, err)
+		}
+
+		if purgeTime.Before(time.Now().Add(retention)) {
+			if _, err := client.RestoreDeletedVolume(element.RestoreDeletedVolumeRequest{VolumeID: volumeID}); err != nil {
+				return fmt.Errorf("The cluster purges deleted volume %v at %v, before delete_retention of %v has passed, and restoring it failed: %v",
+					volumeID, volume.PurgeTime, retention, err)
+			}
+			return fmt.Errorf("Volume %v was restored because the cluster would purge it at %v, before delete_retention of %v has passed",
+				volumeID, volume.PurgeTime, retention)
+		}
+
+		log.Printf("Volume %v is deleted and can be restored until %v", volumeID, volume.PurgeTime)
+		return nil
+	}
+
+	return fmt.Errorf("Deleted volume %v was purged before delete_retention of %v has passed", volumeID, retention)
+}
+
+// resourceSolidFireVolumeCustomizeDiff rejects plans that reduce total_size, because Element cannot shrink a volume,
+// and checks that a retention is given for soft-delete-with-retention
+func resourceSolidFireVolumeCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("delete_behavior").(string) == "soft-delete-with-retention" && d.NewValueKnown("delete_retention") {
+		if _, ok := d.GetOk("delete_retention"); !ok {
+			return fmt.Errorf("delete_retention is required when delete_behavior is soft-delete-with-retention")
+		}
+	}
+
+	if d.Id() == "" || !d.HasChange("total_size") || !d.NewValueKnown("total_size") {
+		return nil
+	}
+
+	// The stored size may already be rounded by the cluster, so compare the sizes the cluster allocates
+	o, n := d.GetChange("total_size")
+	if roundVolumeSize(n.(int)) < roundVolumeSize(o.(int)) {
+		return fmt.Errorf("total_size cannot be reduced from %v to %v bytes because Element volumes cannot be shrunk", o, n)
+	}
+
 	return nil
 }
 
@@ -312,31 +694,23 @@ func resourceSolidFireVolumeExists(d *schema.ResourceData, meta interface{}) (bo
 	log.Printf("Checking existence of volume: %#v", d)
 	client := meta.(*element.Client)
 
-	volumes := element.ListVolumesRequest{}
-
 	id := d.Id()
-	s := make([]int, 1)
 	convID, convErr := strconv.Atoi(id)
 
 	if convErr != nil {
 		return false, fmt.Errorf("id argument is required")
 	}
 
-	s[0] = convID
-	volumes.Volumes = s
-
-	res, err := listVolumes(client, volumes)
+	res, err := client.ListVolumes(element.ListVolumesRequest{VolumeIDs: []int{convID}})
 	if err != nil {
-		if err, ok := err.(*jsonrpc.ResponseError); ok {
-			if err.Name == "xUnknown" {
-				d.SetId("")
-				return false, nil
-			}
+		if errors.Is(err, element.ErrNotFound) {
+			d.SetId("")
+			return false, nil
 		}
 		return false, err
 	}
 
-	if len(res.Volumes) != 1 {
+	if len(res.Volumes) != 1 || res.Volumes[0].Status == "deleted" {
 		d.SetId("")
 		return false, nil
 	}
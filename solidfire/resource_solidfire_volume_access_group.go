@@ -1,46 +1,15 @@
 package solidfire
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
 
-	"encoding/json"
-
-	"github.com/fatih/structs"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/solidfire/terraform-provider-solidfire/solidfire/element"
-	"github.com/solidfire/terraform-provider-solidfire/solidfire/element/jsonrpc"
 )
 
-type CreateVolumeAccessGroupRequest struct {
-	Name       string      `structs:"name"`
-	Initiators []string    `structs:"initiators"`
-	Volumes    []int       `structs:"volumes"`
-	Attributes interface{} `structs:"attributes"`
-	ID         int         `structs:"id"`
-}
-
-type CreateVolumeAccessGroupResult struct {
-	VolumeAccessGroupID int `json:"volumeAccessGroupID"`
-	element.VolumeAccessGroup
-}
-
-type DeleteVolumeAccessGroupRequest struct {
-	VolumeAccessGroupID    int  `structs:"volumeAccessGroupID"`
-	DeleteOrphanInitiators bool `structs:"deleteOrphanInitiators"`
-	Force                  bool `structs:"force"`
-}
-
-type ModifyVolumeAccessGroupRequest struct {
-	VolumeAccessGroupID    int         `structs:"volumeAccessGroupID"`
-	Name                   string      `structs:"name"`
-	Attributes             interface{} `structs:"attributes"`
-	Initiators             []int       `structs:"initiators"`
-	DeleteOrphanInitiators bool        `structs:"deleteOrphanInitiators"`
-	Volumes                []int       `structs:"volumes"`
-}
-
 func resourceSolidFireVolumeAccessGroup() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceSolidFireVolumeAccessGroupCreate,
@@ -51,6 +20,8 @@ func resourceSolidFireVolumeAccessGroup() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		SchemaVersion: attributesSchemaVersion,
+		MigrateState:  migrateAttributesState,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -64,13 +35,8 @@ func resourceSolidFireVolumeAccessGroup() *schema.Resource {
 					Type: schema.TypeInt,
 				},
 			},
-			"attributes": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-			},
+			"attributes":      attributesSchema(),
+			"attributes_json": attributesJSONSchema(),
 			"initiators": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -86,7 +52,7 @@ func resourceSolidFireVolumeAccessGroupCreate(d *schema.ResourceData, meta inter
 	log.Printf("Creating volume access group: %#v", d)
 	client := meta.(*element.Client)
 
-	vag := CreateVolumeAccessGroupRequest{}
+	vag := element.CreateVolumeAccessGroupRequest{}
 
 	if v, ok := d.GetOk("name"); ok {
 		vag.Name = v.(string)
@@ -100,7 +66,17 @@ func resourceSolidFireVolumeAccessGroupCreate(d *schema.ResourceData, meta inter
 		}
 	}
 
-	resp, err := createVolumeAccessGroup(client, vag)
+	attributes, err := expandAttributes(d)
+	if err != nil {
+		return err
+	}
+	if len(attributes) > 0 {
+		vag.Attributes = attributes
+	}
+
+	log.Printf("Parameters: %v", element.Redact(vag))
+
+	resp, err := client.CreateVolumeAccessGroup(vag)
 	if err != nil {
 		log.Print("Error creating volume access group")
 		return err
@@ -112,49 +88,31 @@ func resourceSolidFireVolumeAccessGroupCreate(d *schema.ResourceData, meta inter
 	return resourceSolidFireVolumeAccessGroupRead(d, meta)
 }
 
-func createVolumeAccessGroup(client *element.Client, request CreateVolumeAccessGroupRequest) (CreateVolumeAccessGroupResult, error) {
-	params := structs.Map(request)
-
-	log.Printf("Parameters: %v", params)
-
-	response, err := client.CallAPIMethod("CreateVolumeAccessGroup", params)
-	if err != nil {
-		log.Print("CreateVolumeAccessGroup request failed")
-		return CreateVolumeAccessGroupResult{}, err
-	}
-
-	var result CreateVolumeAccessGroupResult
-	if err := json.Unmarshal([]byte(*response), &result); err != nil {
-		log.Print("Failed to unmarshall response from CreateVolumeAccessGroup")
-		return CreateVolumeAccessGroupResult{}, err
-	}
-	return result, nil
-}
-
 func resourceSolidFireVolumeAccessGroupRead(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("Reading volume access group: %#v", d)
 	client := meta.(*element.Client)
 
-	vags := element.ListVolumeAccessGroupsRequest{}
-
 	id := d.Id()
-	s := make([]int, 1)
 	convID, convErr := strconv.Atoi(id)
 
 	if convErr != nil {
 		return fmt.Errorf("id argument is required")
 	}
 
-	s[0] = convID
-	vags.VolumeAccessGroups = s
-
-	res, err := listVolumeAccessGroups(client, vags)
+	res, err := client.ListVolumeAccessGroups(element.ListVolumeAccessGroupsRequest{VolumeAccessGroups: []int{convID}})
 	if err != nil {
+		if errors.Is(err, element.ErrNotFound) {
+			log.Printf("Volume access group %v not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 
-	if len(res.VolumeAccessGroupsNotFound) > 0 {
-		return fmt.Errorf("Unable to find Volume Access Groups with the ID of %v", res.VolumeAccessGroupsNotFound)
+	if len(res.VolumeAccessGroupsNotFound) > 0 || len(res.VolumeAccessGroups) == 0 {
+		log.Printf("Volume access group %v not found, removing from state", id)
+		d.SetId("")
+		return nil
 	}
 
 	if len(res.VolumeAccessGroups) != 1 {
@@ -165,32 +123,18 @@ func resourceSolidFireVolumeAccessGroupRead(d *schema.ResourceData, meta interfa
 	d.Set("initiators", res.VolumeAccessGroups[0].Initiators)
 	d.Set("volumes", res.VolumeAccessGroups[0].Volumes)
 
-	return nil
-}
-
-func listVolumeAccessGroups(client *element.Client, request element.ListVolumeAccessGroupsRequest) (element.ListVolumeAccessGroupsResult, error) {
-	params := structs.Map(request)
-
-	response, err := client.CallAPIMethod("ListVolumeAccessGroups", params)
-	if err != nil {
-		log.Print("ListVolumeAccessGroups request failed")
-		return element.ListVolumeAccessGroupsResult{}, err
-	}
-
-	var result element.ListVolumeAccessGroupsResult
-	if err := json.Unmarshal([]byte(*response), &result); err != nil {
-		log.Print("Failed to unmarshall response from ListVolumeAccessGroups")
-		return element.ListVolumeAccessGroupsResult{}, err
+	if err := setAttributes(d, res.VolumeAccessGroups[0].Attributes); err != nil {
+		return err
 	}
 
-	return result, nil
+	return nil
 }
 
 func resourceSolidFireVolumeAccessGroupUpdate(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("Updating volume access group %#v", d)
 	client := meta.(*element.Client)
 
-	vag := ModifyVolumeAccessGroupRequest{}
+	vag := element.ModifyVolumeAccessGroupRequest{}
 
 	id := d.Id()
 	convID, convErr := strconv.Atoi(id)
@@ -215,20 +159,18 @@ func resourceSolidFireVolumeAccessGroupUpdate(d *schema.ResourceData, meta inter
 		return fmt.Errorf("expecting an array of volume ids to change")
 	}
 
-	err := modifyVolumeAccessGroup(client, vag)
-	if err != nil {
-		return err
+	if attributesChanged(d) {
+		attributes, err := expandAttributes(d)
+		if err != nil {
+			return err
+		}
+		vag.Attributes = attributes
 	}
 
-	return nil
-}
+	log.Printf("Parameters: %v", element.Redact(vag))
 
-func modifyVolumeAccessGroup(client *element.Client, request ModifyVolumeAccessGroupRequest) error {
-	params := structs.Map(request)
-
-	_, err := client.CallAPIMethod("ModifyVolumeAccessGroup", params)
+	_, err := client.ModifyVolumeAccessGroup(vag)
 	if err != nil {
-		log.Print("ModifyVolumeAccessGroup request failed")
 		return err
 	}
 
@@ -239,17 +181,14 @@ func resourceSolidFireVolumeAccessGroupDelete(d *schema.ResourceData, meta inter
 	log.Printf("Deleting volume access group: %#v", d)
 	client := meta.(*element.Client)
 
-	vag := DeleteVolumeAccessGroupRequest{}
-
 	id := d.Id()
 	convID, convErr := strconv.Atoi(id)
 
 	if convErr != nil {
 		return fmt.Errorf("id argument is required")
 	}
-	vag.VolumeAccessGroupID = convID
 
-	err := deleteVolumeAccessGroup(client, vag)
+	_, err := client.DeleteVolumeAccessGroup(element.DeleteVolumeAccessGroupRequest{VolumeAccessGroupID: convID})
 	if err != nil {
 		return err
 	}
@@ -257,42 +196,22 @@ func resourceSolidFireVolumeAccessGroupDelete(d *schema.ResourceData, meta inter
 	return nil
 }
 
-func deleteVolumeAccessGroup(client *element.Client, request DeleteVolumeAccessGroupRequest) error {
-	params := structs.Map(request)
-
-	_, err := client.CallAPIMethod("DeleteVolumeAccessGroup", params)
-	if err != nil {
-		log.Print("DeleteVolumeAccessGroup request failed")
-		return err
-	}
-
-	return nil
-}
-
 func resourceSolidFireVolumeAccessGroupExists(d *schema.ResourceData, meta interface{}) (bool, error) {
 	log.Printf("Checking existence of volume access group: %#v", d)
 	client := meta.(*element.Client)
 
-	vags := element.ListVolumeAccessGroupsRequest{}
-
 	id := d.Id()
-	s := make([]int, 1)
 	convID, convErr := strconv.Atoi(id)
 
 	if convErr != nil {
 		return false, fmt.Errorf("id argument is required")
 	}
 
-	s[0] = convID
-	vags.VolumeAccessGroups = s
-
-	res, err := listVolumeAccessGroups(client, vags)
+	res, err := client.ListVolumeAccessGroups(element.ListVolumeAccessGroupsRequest{VolumeAccessGroups: []int{convID}})
 	if err != nil {
-		if err, ok := err.(*jsonrpc.ResponseError); ok {
-			if err.Name == "xUnknown" {
-				d.SetId("")
-				return false, nil
-			}
+		if errors.Is(err, element.ErrNotFound) {
+			d.SetId("")
+			return false, nil
 		}
 		return false, err
 	}
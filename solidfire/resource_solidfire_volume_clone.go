@@ -0,0 +1,209 @@
+package solidfire
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element"
+)
+
+// resourceSolidFireVolumeClone manages a volume created by cloning another volume or one of its snapshots.
+// Once the clone has finished it is read, updated and deleted like a solidfire_volume.
+func resourceSolidFireVolumeClone() *schema.Resource {
+	volume := resourceSolidFireVolume()
+
+	s := volume.Schema
+	delete(s, "restore_deleted")
+
+	s["source_volume_id"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Required: true,
+		ForceNew: true,
+	}
+	s["source_snapshot_id"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Optional: true,
+		ForceNew: true,
+	}
+	// The clone inherits these settings from the source unless they are configured
+	s["account_id"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Optional: true,
+		Computed: true,
+	}
+	s["total_size"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Optional: true,
+		Computed: true,
+	}
+	s["enable512e"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Computed: true,
+		ForceNew: true,
+	}
+
+	return &schema.Resource{
+		Create: resourceSolidFireVolumeCloneCreate,
+		Read:   resourceSolidFireVolumeRead,
+		Update: resourceSolidFireVolumeUpdate,
+		Delete: resourceSolidFireVolumeDelete,
+		Exists: resourceSolidFireVolumeExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceSolidFireVolumeCloneImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+		SchemaVersion: volume.SchemaVersion,
+		MigrateState:  volume.MigrateState,
+		CustomizeDiff: volume.CustomizeDiff,
+
+		Schema: s,
+	}
+}
+
+func resourceSolidFireVolumeCloneCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Creating volume clone: %#v", d)
+	client := meta.(*element.Client)
+
+	clone := element.CloneVolumeRequest{}
+	qos := element.QoS{}
+
+	if v, ok := d.GetOk("source_volume_id"); ok {
+		clone.VolumeID = v.(int)
+	} else {
+		return fmt.Errorf("source_volume_id argument is required")
+	}
+
+	if v, ok := d.GetOk("name"); ok {
+		clone.Name = v.(string)
+	} else {
+		return fmt.Errorf("name argument is required")
+	}
+
+	if v, ok := d.GetOk("source_snapshot_id"); ok {
+		clone.SnapshotID = v.(int)
+	}
+
+	if v, ok := d.GetOk("account_id"); ok {
+		clone.NewAccountID = v.(int)
+	}
+
+	if v, ok := d.GetOk("total_size"); ok {
+		clone.NewSize = v.(int)
+	}
+
+	if v, ok := d.GetOk("access"); ok {
+		clone.Access = v.(string)
+	}
+
+	if v, ok := d.GetOkExists("enable512e"); ok {
+		enable512e := v.(bool)
+		clone.Enable512e = &enable512e
+	}
+
+	attributes, err := expandAttributes(d)
+	if err != nil {
+		return err
+	}
+	if len(attributes) > 0 {
+		clone.Attributes = attributes
+	}
+
+	log.Printf("Parameters: %v", element.Redact(clone))
+
+	resp, err := client.CloneVolume(clone)
+	if err != nil {
+		log.Print("Error cloning volume")
+		return err
+	}
+
+	// Keep the ID even if the clone fails, so that Terraform taints the incomplete volume and deletes it
+	d.SetId(strconv.Itoa(resp.VolumeID))
+	log.Printf("Started clone %v of volume %v into volume %v", resp.CloneID, clone.VolumeID, resp.VolumeID)
+
+	ctx, cancel := context.WithTimeout(client.Context(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	if err := client.WaitForAsyncResultContext(ctx, resp.AsyncHandle, nil); err != nil {
+		return fmt.Errorf("Clone %v of volume %v into volume %v failed: %v", resp.CloneID, clone.VolumeID, resp.VolumeID, err)
+	}
+
+	log.Printf("Created volume clone: %v %v", clone.Name, resp.VolumeID)
+
+	// CloneVolume copies the QoS of the source and cannot set FIFO snapshot sizes, so configured QoS and FIFO
+	// settings are applied afterwards
+	modify := element.ModifyVolumeRequest{VolumeID: resp.VolumeID}
+
+	if v, ok := d.GetOk("qos_policy_id"); ok {
+		modify.QoSPolicyID = v.(int)
+	}
+
+	if v, ok := d.GetOk("min_iops"); ok {
+		qos.MinIOPS = v.(int)
+	}
+
+	if v, ok := d.GetOk("max_iops"); ok {
+		qos.MaxIOPS = v.(int)
+	}
+
+	if v, ok := d.GetOk("burst_iops"); ok {
+		qos.BurstIOPS = v.(int)
+	}
+
+	if qos != (element.QoS{}) {
+		modify.QoS = &qos
+	}
+
+	if v, ok := d.GetOk("fifo_size"); ok {
+		modify.FifoSize = v.(int)
+	}
+
+	if v, ok := d.GetOkExists("min_fifo_size"); ok {
+		minFifoSize := v.(int)
+		modify.MinFifoSize = &minFifoSize
+	}
+
+	if modify.QoS != nil || modify.QoSPolicyID != 0 || modify.FifoSize != 0 || modify.MinFifoSize != nil {
+		log.Printf("Parameters: %v", element.Redact(modify))
+
+		if _, err := client.ModifyVolume(modify); err != nil {
+			return err
+		}
+	}
+
+	return resourceSolidFireVolumeRead(d, meta)
+}
+
+// resourceSolidFireVolumeCloneImport imports a clone from an ID of the form <volume ID>:<source volume ID>, or
+// <volume ID>:<source volume ID>:<source snapshot ID>, because the cluster does not record where a volume
+// was cloned from
+func resourceSolidFireVolumeCloneImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return nil, fmt.Errorf("Unexpected format of ID (%q), expected <volume ID>:<source volume ID>[:<source snapshot ID>]", d.Id())
+	}
+
+	ids := make([]int, len(parts))
+	for i, part := range parts {
+		id, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("Unexpected format of ID (%q), %q is not a number", d.Id(), part)
+		}
+		ids[i] = id
+	}
+
+	d.SetId(parts[0])
+	d.Set("source_volume_id", ids[1])
+	if len(ids) == 3 {
+		d.Set("source_snapshot_id", ids[2])
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,100 @@
+package solidfire
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element"
+)
+
+func TestVolumeClone_basic(t *testing.T) {
+	var volume element.Volume
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireVolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireVolumeCloneConfig, `
+	access = "readOnly"
+	attributes = {
+		source = "golden"
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireVolumeExists("solidfire_volume_clone.terraform-acceptance-test-1", &volume),
+					resource.TestCheckResourceAttr("solidfire_volume_clone.terraform-acceptance-test-1", "name", "terraform-acceptance-test-clone"),
+					resource.TestCheckResourceAttrPair("solidfire_volume_clone.terraform-acceptance-test-1", "account_id", "solidfire_account.terraform-acceptance-test-1", "id"),
+					resource.TestCheckResourceAttr("solidfire_volume_clone.terraform-acceptance-test-1", "total_size", "1073741824"),
+					resource.TestCheckResourceAttr("solidfire_volume_clone.terraform-acceptance-test-1", "enable512e", "true"),
+					resource.TestCheckResourceAttr("solidfire_volume_clone.terraform-acceptance-test-1", "access", "readOnly"),
+					resource.TestCheckResourceAttr("solidfire_volume_clone.terraform-acceptance-test-1", "min_iops", "500"),
+					resource.TestCheckResourceAttr("solidfire_volume_clone.terraform-acceptance-test-1", "attributes.source", "golden"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireVolumeCloneConfig, `
+	access = "readWrite"
+	total_size = "2147483648"
+	min_iops = "1000"
+	max_iops = "12000"
+	burst_iops = "15000"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireVolumeExists("solidfire_volume_clone.terraform-acceptance-test-1", &volume),
+					testAccCheckSolidFireVolumeAttributes(&volume, 2147483648, 1000, 12000, 15000),
+					testAccCheckSolidFireVolumeAccess(&volume, "readWrite"),
+					resource.TestCheckResourceAttr("solidfire_volume_clone.terraform-acceptance-test-1", "attributes.%", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestVolumeClone_resizeAndAccount(t *testing.T) {
+	var volume element.Volume
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireVolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireVolumeCloneConfig, `
+	account_id = "${solidfire_account.terraform-acceptance-test-2.id}"
+	total_size = "2147483648"
+	enable512e = "false"
+	min_iops = "1000"
+	max_iops = "12000"
+	burst_iops = "15000"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireVolumeExists("solidfire_volume_clone.terraform-acceptance-test-1", &volume),
+					testAccCheckSolidFireVolumeAttributes(&volume, 2147483648, 1000, 12000, 15000),
+					resource.TestCheckResourceAttrPair("solidfire_volume_clone.terraform-acceptance-test-1", "account_id", "solidfire_account.terraform-acceptance-test-2", "id"),
+					resource.TestCheckResourceAttr("solidfire_volume_clone.terraform-acceptance-test-1", "enable512e", "false"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckSolidFireVolumeCloneConfig = `
+resource "solidfire_volume_clone" "terraform-acceptance-test-1" {
+	name = "terraform-acceptance-test-clone"
+	source_volume_id = "${solidfire_volume.terraform-acceptance-test-1.id}"
+	%s
+}
+resource "solidfire_volume" "terraform-acceptance-test-1" {
+	name = "terraform-acceptance-test-golden"
+	account_id = "${solidfire_account.terraform-acceptance-test-1.id}"
+	total_size = "1073741824"
+	enable512e = "true"
+	min_iops = "500"
+	max_iops = "10000"
+	burst_iops = "10000"
+}
+resource "solidfire_account" "terraform-acceptance-test-1" {
+	username = "terraform-acceptance-test-clone"
+}
+resource "solidfire_account" "terraform-acceptance-test-2" {
+	username = "terraform-acceptance-test-clone-2"
+}
+`
@@ -0,0 +1,158 @@
+package solidfire
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element"
+)
+
+// resourceSolidFireVolumeRollback rolls a volume back to a snapshot when it is created, and again whenever
+// snapshot_id or trigger change. Destroying it leaves the volume as it is.
+func resourceSolidFireVolumeRollback() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceSolidFireVolumeRollbackCreate,
+		Read:          resourceSolidFireVolumeRollbackRead,
+		Update:        resourceSolidFireVolumeRollbackUpdate,
+		Delete:        resourceSolidFireVolumeRollbackDelete,
+		CustomizeDiff: customizeDiffRollback("snapshot_id", "saved_snapshot_id"),
+
+		Schema: map[string]*schema.Schema{
+			"volume_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"snapshot_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"save_current_state": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"saved_snapshot_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"saved_snapshot_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// customizeDiffRollback returns a CustomizeDiffFunc that marks the ID of the safety snapshot as unknown
+// when a change to source or trigger makes the next apply roll back again
+func customizeDiffRollback(source string, saved string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		if d.Id() != "" && (d.HasChange(source) || d.HasChange("trigger")) {
+			return d.SetNewComputed(saved)
+		}
+		return nil
+	}
+}
+
+func resourceSolidFireVolumeRollbackCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Creating volume rollback: %#v", d)
+
+	if err := rollbackVolume(d, meta.(*element.Client)); err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(d.Get("volume_id").(int)))
+
+	return resourceSolidFireVolumeRollbackRead(d, meta)
+}
+
+func resourceSolidFireVolumeRollbackRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Reading volume rollback: %#v", d)
+	client := meta.(*element.Client)
+
+	id := d.Id()
+	convID, convErr := strconv.Atoi(id)
+
+	if convErr != nil {
+		return fmt.Errorf("id argument is required")
+	}
+
+	// The rollback itself leaves nothing to read. It is only dropped from state when its volume is gone, and
+	// not when the snapshot expires, so that an expired snapshot does not cause another rollback.
+	res, err := client.ListVolumes(element.ListVolumesRequest{VolumeIDs: []int{convID}})
+	if err != nil {
+		if errors.Is(err, element.ErrNotFound) {
+			log.Printf("Volume %v not found, removing rollback from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	if len(res.Volumes) != 1 || res.Volumes[0].Status == "deleted" {
+		log.Printf("Volume %v not found, removing rollback from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	return nil
+}
+
+func resourceSolidFireVolumeRollbackUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Updating volume rollback %#v", d)
+
+	if d.HasChange("snapshot_id") || d.HasChange("trigger") {
+		// Keep the old values in state if the rollback fails, so that the next apply retries it
+		d.Partial(true)
+		if err := rollbackVolume(d, meta.(*element.Client)); err != nil {
+			return err
+		}
+		d.Partial(false)
+	}
+
+	return resourceSolidFireVolumeRollbackRead(d, meta)
+}
+
+func resourceSolidFireVolumeRollbackDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Deleting volume rollback: %#v", d)
+	log.Printf("[INFO] Volume %v is left at snapshot %v; destroying a rollback does not undo it", d.Get("volume_id"), d.Get("snapshot_id"))
+	return nil
+}
+
+// rollbackVolume rolls the volume back to the configured snapshot and records the safety snapshot, if any
+func rollbackVolume(d *schema.ResourceData, client *element.Client) error {
+	rollback := element.RollbackToSnapshotRequest{
+		VolumeID:         d.Get("volume_id").(int),
+		SnapshotID:       d.Get("snapshot_id").(int),
+		SaveCurrentState: d.Get("save_current_state").(bool),
+	}
+
+	if v, ok := d.GetOk("saved_snapshot_name"); ok && rollback.SaveCurrentState {
+		rollback.Name = v.(string)
+	}
+
+	log.Printf("Parameters: %v", element.Redact(rollback))
+
+	resp, err := client.RollbackToSnapshot(rollback)
+	if err != nil {
+		log.Print("Error rolling back volume")
+		return err
+	}
+
+	log.Printf("Rolled back volume %v to snapshot %v", rollback.VolumeID, rollback.SnapshotID)
+	if rollback.SaveCurrentState {
+		log.Printf("Saved state of volume %v before rollback as snapshot %v", rollback.VolumeID, resp.SnapshotID)
+	}
+
+	d.Set("saved_snapshot_id", resp.SnapshotID)
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package solidfire
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/solidfire/terraform-provider-solidfire/solidfire/element"
+)
+
+func TestVolumeRollback_trigger(t *testing.T) {
+	var saved int
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireVolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireVolumeRollbackConfig, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireRollbackSaved("solidfire_volume_rollback.terraform-acceptance-test-1", "saved_snapshot_id", &saved, true),
+					testAccCheckSolidFireSavedSnapshot(&saved, "terraform-acceptance-test-before-rollback"),
+					resource.TestCheckResourceAttrPair("solidfire_volume_rollback.terraform-acceptance-test-1", "id", "solidfire_volume.terraform-acceptance-test-1", "id"),
+				),
+			},
+			{
+				// An unchanged trigger does not roll back again
+				Config: fmt.Sprintf(testAccCheckSolidFireVolumeRollbackConfig, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireRollbackSaved("solidfire_volume_rollback.terraform-acceptance-test-1", "saved_snapshot_id", &saved, false),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireVolumeRollbackConfig, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireRollbackSaved("solidfire_volume_rollback.terraform-acceptance-test-1", "saved_snapshot_id", &saved, true),
+					testAccCheckSolidFireSavedSnapshot(&saved, "terraform-acceptance-test-before-rollback"),
+				),
+			},
+		},
+	})
+}
+
+func TestVolumeRollback_failed(t *testing.T) {
+	var saved int
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckFakeCluster(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireVolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireVolumeRollbackConfig, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireRollbackSaved("solidfire_volume_rollback.terraform-acceptance-test-1", "saved_snapshot_id", &saved, true),
+				),
+			},
+			{
+				PreConfig: func() {
+					testAccFakeCluster.InjectError("RollbackToSnapshot", "xSnapshotBusy")
+				},
+				Config:      fmt.Sprintf(testAccCheckSolidFireVolumeRollbackConfig, "2"),
+				ExpectError: regexp.MustCompile("xSnapshotBusy"),
+			},
+			{
+				// The failed rollback did not record the new trigger, so it is retried
+				Config: fmt.Sprintf(testAccCheckSolidFireVolumeRollbackConfig, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireRollbackSaved("solidfire_volume_rollback.terraform-acceptance-test-1", "saved_snapshot_id", &saved, true),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckSolidFireRollbackSaved checks the ID of the safety snapshot in key of rollback n against the
+// ID recorded by the previous step, which changes if and only if the step rolled back again
+func testAccCheckSolidFireRollbackSaved(n string, key string, saved *int, changed bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		id, err := strconv.Atoi(rs.Primary.Attributes[key])
+		if err != nil {
+			return err
+		}
+		if id == 0 {
+			return fmt.Errorf("No safety snapshot was saved by %s", n)
+		}
+
+		if changed && id == *saved {
+			return fmt.Errorf("Expected a new safety snapshot, %s is still %v", key, id)
+		}
+		if !changed && id != *saved {
+			return fmt.Errorf("Expected no rollback, but %s changed from %v to %v", key, *saved, id)
+		}
+
+		*saved = id
+		return nil
+	}
+}
+
+func testAccCheckSolidFireSavedSnapshot(id *int, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		virConn := testAccProvider.Meta().(*element.Client)
+
+		res, err := virConn.ListSnapshots(element.ListSnapshotsRequest{SnapshotID: *id})
+		if err != nil {
+			return err
+		}
+		if len(res.Snapshots) != 1 {
+			return fmt.Errorf("Safety snapshot %v not found", *id)
+		}
+		if res.Snapshots[0].Name != name {
+			return fmt.Errorf("Expected safety snapshot %v to be named %q, got %q", *id, name, res.Snapshots[0].Name)
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSolidFireVolumeRollbackConfig = `
+resource "solidfire_volume_rollback" "terraform-acceptance-test-1" {
+	volume_id = "${solidfire_volume.terraform-acceptance-test-1.id}"
+	snapshot_id = "${solidfire_snapshot.terraform-acceptance-test-1.id}"
+	trigger = "%s"
+	save_current_state = true
+	saved_snapshot_name = "terraform-acceptance-test-before-rollback"
+}
+resource "solidfire_snapshot" "terraform-acceptance-test-1" {
+	volume_id = "${solidfire_volume.terraform-acceptance-test-1.id}"
+	name = "terraform-acceptance-test-rollback"
+}
+resource "solidfire_volume" "terraform-acceptance-test-1" {
+	name = "terraform-acceptance-test-rollback"
+	account_id = "${solidfire_account.terraform-acceptance-test-1.id}"
+	total_size = "1073741824"
+	enable512e = "true"
+}
+resource "solidfire_account" "terraform-acceptance-test-1" {
+	username = "terraform-acceptance-test-rollback"
+}
+`
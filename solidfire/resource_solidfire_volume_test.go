@@ -1,8 +1,10 @@
 package solidfire
 
 import (
+	"regexp"
 	"strconv"
 	"testing"
+	"time"
 
 	"fmt"
 
@@ -31,22 +33,532 @@ func TestVolume_basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckSolidFireVolumeExists("solidfire_volume.terraform-acceptance-test-1", &volume),
 					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "name", "terraform-acceptance-test"),
-					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "totalSize", "1000000000"),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "total_size", "1000000000"),
 					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "enable512e", "true"),
-					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "minIOPS", "500"),
-					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "maxIOPS", "10000"),
-					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "burstIOPS", "10000"),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "min_iops", "500"),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "max_iops", "10000"),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "burst_iops", "10000"),
 				),
 			},
 		},
 	})
 }
 
+func TestVolume_update(t *testing.T) {
+	var volume element.Volume
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireVolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(
+					testAccCheckSolidFireVolumeConfig,
+					"terraform-acceptance-test",
+					"1000000000",
+					"true",
+					"500",
+					"10000",
+					"10000",
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireVolumeExists("solidfire_volume.terraform-acceptance-test-1", &volume),
+				),
+			},
+			{
+				Config: fmt.Sprintf(
+					testAccCheckSolidFireVolumeConfig,
+					"terraform-acceptance-test",
+					"2000000000",
+					"true",
+					"1000",
+					"12000",
+					"15000",
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireVolumeExists("solidfire_volume.terraform-acceptance-test-1", &volume),
+					testAccCheckSolidFireVolumeAttributes(&volume, 2000000000, 1000, 12000, 15000),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "total_size", "2000000000"),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "min_iops", "1000"),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "max_iops", "12000"),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "burst_iops", "15000"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(
+					testAccCheckSolidFireVolumeConfig,
+					"terraform-acceptance-test",
+					"1000000000",
+					"true",
+					"1000",
+					"12000",
+					"15000",
+				),
+				ExpectError: regexp.MustCompile("total_size cannot be reduced"),
+			},
+			{
+				Config: fmt.Sprintf(
+					testAccCheckSolidFireVolumeConfig,
+					"terraform-acceptance-test-renamed",
+					"2000000000",
+					"false",
+					"1000",
+					"12000",
+					"15000",
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireVolumeRecreated("solidfire_volume.terraform-acceptance-test-1", &volume),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "name", "terraform-acceptance-test-renamed"),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "enable512e", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestVolume_qosValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireVolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(
+					testAccCheckSolidFireVolumeConfig,
+					"terraform-acceptance-test",
+					"1000000000",
+					"true",
+					"20000",
+					"500",
+					"500",
+				),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid QoS settings: minIOPS must be between"),
+			},
+			{
+				Config: fmt.Sprintf(
+					testAccCheckSolidFireVolumeConfig,
+					"terraform-acceptance-test",
+					"1000000000",
+					"true",
+					"1000",
+					"500",
+					"500",
+				),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("minIOPS \\(1000\\) must not be greater than maxIOPS \\(500\\)"),
+			},
+			{
+				Config: fmt.Sprintf(
+					testAccCheckSolidFireVolumeConfig,
+					"terraform-acceptance-test",
+					"1000000000",
+					"true",
+					"500",
+					"10000",
+					"500000",
+				),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("burstIOPS must be between 100 and 200000"),
+			},
+		},
+	})
+}
+
+func TestVolume_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireVolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(
+					testAccCheckSolidFireVolumeConfig,
+					"terraform-acceptance-test",
+					"1073741824",
+					"false",
+					"500",
+					"10000",
+					"10000",
+				),
+			},
+			{
+				ResourceName:      "solidfire_volume.terraform-acceptance-test-1",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Settings that only affect how the provider manages the volume cannot be imported
+				ImportStateVerifyIgnore: []string{"delete_behavior", "restore_deleted"},
+			},
+		},
+	})
+}
+
+func TestVolume_importUnalignedSize(t *testing.T) {
+	var volume element.Volume
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireVolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(
+					testAccCheckSolidFireVolumeConfig,
+					"terraform-acceptance-test",
+					"1000000000",
+					"true",
+					"500",
+					"10000",
+					"10000",
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireVolumeExists("solidfire_volume.terraform-acceptance-test-1", &volume),
+				),
+			},
+			{
+				ResourceName:      "solidfire_volume.terraform-acceptance-test-1",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The imported size is the size the cluster rounded the configured size to
+				ImportStateVerifyIgnore: []string{"delete_behavior", "restore_deleted", "total_size"},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("Expected one imported volume, got %v", len(states))
+					}
+					if size := states[0].Attributes["total_size"]; size != strconv.Itoa(roundVolumeSize(1000000000)) {
+						return fmt.Errorf("Expected the rounded size to be imported, got %v", size)
+					}
+					return nil
+				},
+			},
+			{
+				// Growing the volume outside of Terraform stores the rounded size of the cluster in state, as
+				// an import does. Configuring the new size must not be reported as a shrink.
+				PreConfig: func() {
+					virConn := testAccProvider.Meta().(*element.Client)
+					_, err := virConn.ModifyVolume(element.ModifyVolumeRequest{
+						VolumeID:  volume.VolumeID,
+						TotalSize: 2000000000,
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: fmt.Sprintf(
+					testAccCheckSolidFireVolumeConfig,
+					"terraform-acceptance-test",
+					"2000000000",
+					"true",
+					"500",
+					"10000",
+					"10000",
+				),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestVolume_fifo(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireVolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireVolumeFifoConfig, "", 8, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "fifo_size", "8"),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "min_fifo_size", "2"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireVolumeFifoConfig, "", 16, 0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "fifo_size", "16"),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "min_fifo_size", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestVolume_fifoRequiresAPIVersion(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireVolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireVolumeFifoConfig, `
+provider "solidfire" {
+	api_version = "11.0"
+}`, 8, 2),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("fifo_size: .*requires Element API 12.0"),
+			},
+		},
+	})
+}
+
+func TestVolume_drift(t *testing.T) {
+	var volume element.Volume
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireVolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(
+					testAccCheckSolidFireVolumeConfig,
+					"terraform-acceptance-test",
+					"1000000000",
+					"true",
+					"500",
+					"10000",
+					"10000",
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireVolumeExists("solidfire_volume.terraform-acceptance-test-1", &volume),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "access", "readWrite"),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "status", "active"),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "block_size", "4096"),
+					resource.TestCheckResourceAttrSet("solidfire_volume.terraform-acceptance-test-1", "scsi_naa_device_id"),
+					resource.TestCheckResourceAttrSet("solidfire_volume.terraform-acceptance-test-1", "scsi_eui_device_id"),
+					resource.TestCheckResourceAttrSet("solidfire_volume.terraform-acceptance-test-1", "create_time"),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "volume_pairs.#", "0"),
+				),
+			},
+			{
+				PreConfig: func() {
+					virConn := testAccProvider.Meta().(*element.Client)
+					_, err := virConn.ModifyVolume(element.ModifyVolumeRequest{
+						VolumeID: volume.VolumeID,
+						QoS:      &element.QoS{MinIOPS: 1000, MaxIOPS: 12000, BurstIOPS: 15000},
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: fmt.Sprintf(
+					testAccCheckSolidFireVolumeConfig,
+					"terraform-acceptance-test",
+					"1000000000",
+					"true",
+					"500",
+					"10000",
+					"10000",
+				),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestVolume_access(t *testing.T) {
+	var volume element.Volume
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireVolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireVolumeAccessConfig, "readOnly"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireVolumeExists("solidfire_volume.terraform-acceptance-test-1", &volume),
+					testAccCheckSolidFireVolumeAccess(&volume, "readOnly"),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "access", "readOnly"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireVolumeAccessConfig, "locked"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireVolumeExists("solidfire_volume.terraform-acceptance-test-1", &volume),
+					testAccCheckSolidFireVolumeAccess(&volume, "locked"),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "access", "locked"),
+				),
+			},
+			{
+				Config:      fmt.Sprintf(testAccCheckSolidFireVolumeAccessConfig, "readwrite"),
+				ExpectError: regexp.MustCompile("expected access to be one of"),
+			},
+		},
+	})
+}
+
+func TestVolume_softDelete(t *testing.T) {
+	var volume element.Volume
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireVolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireVolumeDeleteConfig, `delete_behavior = "soft-delete"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireVolumeExists("solidfire_volume.terraform-acceptance-test-1", &volume),
+				),
+			},
+			{
+				Config: testAccCheckSolidFireVolumeAccountOnlyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireVolumeSoftDeleted(&volume),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireVolumeDeleteConfig, "restore_deleted = true"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireVolumeRestored("solidfire_volume.terraform-acceptance-test-1", &volume),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "status", "active"),
+				),
+			},
+		},
+	})
+}
+
+func TestVolume_restoreLastDeleted(t *testing.T) {
+	var first, second element.Volume
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireVolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSolidFireVolumeTwinsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireVolumeExists("solidfire_volume.terraform-acceptance-test-1", &first),
+					testAccCheckSolidFireVolumeExists("solidfire_volume.terraform-acceptance-test-2", &second),
+				),
+			},
+			{
+				// Delete the newer volume first, so the older volume is the most recently deleted one
+				PreConfig: func() {
+					older, newer := first, second
+					if newer.VolumeID < older.VolumeID {
+						older, newer = newer, older
+					}
+					virConn := testAccProvider.Meta().(*element.Client)
+					if _, err := virConn.DeleteVolume(element.DeleteVolumeRequest{VolumeID: newer.VolumeID}); err != nil {
+						t.Fatal(err)
+					}
+					// Delete times have second precision
+					time.Sleep(1100 * time.Millisecond)
+					if _, err := virConn.DeleteVolume(element.DeleteVolumeRequest{VolumeID: older.VolumeID}); err != nil {
+						t.Fatal(err)
+					}
+					first, second = older, newer
+				},
+				Config: testAccCheckSolidFireVolumeAccountOnlyConfig,
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireVolumeDeleteConfig, "restore_deleted = true"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireVolumeRestored("solidfire_volume.terraform-acceptance-test-1", &first),
+					testAccPurgeSolidFireVolume(&second),
+				),
+			},
+		},
+	})
+}
+
+func TestVolume_softDeleteWithRetention(t *testing.T) {
+	var volume element.Volume
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireVolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccCheckSolidFireVolumeDeleteConfig, `delete_behavior = "soft-delete-with-retention"`),
+				ExpectError: regexp.MustCompile("delete_retention is required"),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireVolumeDeleteConfig, `
+	delete_behavior = "soft-delete-with-retention"
+	delete_retention = "720h"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireVolumeExists("solidfire_volume.terraform-acceptance-test-1", &volume),
+				),
+			},
+			{
+				Config:      testAccCheckSolidFireVolumeAccountOnlyConfig,
+				ExpectError: regexp.MustCompile("before delete_retention of 720h0m0s has passed"),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireVolumeDeleteConfig, `
+	delete_behavior = "soft-delete-with-retention"
+	delete_retention = "1h"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireVolumeRestored("solidfire_volume.terraform-acceptance-test-1", &volume),
+				),
+			},
+			{
+				Config: testAccCheckSolidFireVolumeAccountOnlyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireVolumeSoftDeleted(&volume),
+					testAccPurgeSolidFireVolume(&volume),
+				),
+			},
+		},
+	})
+}
+
+func TestVolume_attributes(t *testing.T) {
+	var volume element.Volume
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSolidFireVolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireVolumeDeleteConfig, `attributes = {
+		owner = "ops"
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireVolumeExists("solidfire_volume.terraform-acceptance-test-1", &volume),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "attributes.%", "1"),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "attributes.owner", "ops"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSolidFireVolumeDeleteConfig, `attributes = {
+		owner = "dba"
+		tier = "gold"
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSolidFireVolumeExists("solidfire_volume.terraform-acceptance-test-1", &volume),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "attributes.%", "2"),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "attributes.owner", "dba"),
+					resource.TestCheckResourceAttr("solidfire_volume.terraform-acceptance-test-1", "attributes.tier", "gold"),
+				),
+			},
+			{
+				PreConfig: func() {
+					virConn := testAccProvider.Meta().(*element.Client)
+					_, err := virConn.ModifyVolume(element.ModifyVolumeRequest{
+						VolumeID:   volume.VolumeID,
+						Attributes: map[string]interface{}{"owner": "someone-else"},
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: fmt.Sprintf(testAccCheckSolidFireVolumeDeleteConfig, `attributes = {
+		owner = "dba"
+		tier = "gold"
+	}`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
 func testAccCheckSolidFireVolumeDestroy(s *terraform.State) error {
 	virConn := testAccProvider.Meta().(*element.Client)
 
 	for _, rs := range s.RootModule().Resources {
-		if rs.Type != "solidfire_volume" {
+		if rs.Type != "solidfire_volume" && rs.Type != "solidfire_volume_clone" {
 			continue
 		}
 
@@ -92,15 +604,174 @@ func testAccCheckSolidFireVolumeExists(n string, volume *element.Volume) resourc
 	}
 }
 
+func testAccCheckSolidFireVolumeAttributes(volume *element.Volume, totalSize int, minIOPS int, maxIOPS int, burstIOPS int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if volume.TotalSize < totalSize {
+			return fmt.Errorf("Expected a total size of at least %v, got %v", totalSize, volume.TotalSize)
+		}
+
+		if volume.QoS.MinIOPS != minIOPS || volume.QoS.MaxIOPS != maxIOPS || volume.QoS.BurstIOPS != burstIOPS {
+			return fmt.Errorf("Expected QoS %v/%v/%v, got %v/%v/%v", minIOPS, maxIOPS, burstIOPS,
+				volume.QoS.MinIOPS, volume.QoS.MaxIOPS, volume.QoS.BurstIOPS)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckSolidFireVolumeRecreated checks that the volume n replaced volume, which must have been deleted
+func testAccCheckSolidFireVolumeRecreated(n string, volume *element.Volume) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == strconv.Itoa(volume.VolumeID) {
+			return fmt.Errorf("Volume %v was modified in place instead of being recreated", volume.VolumeID)
+		}
+
+		virConn := testAccProvider.Meta().(*element.Client)
+		res, err := virConn.ListVolumes(element.ListVolumesRequest{VolumeIDs: []int{volume.VolumeID}})
+		if err == nil && len(res.Volumes) > 0 && res.Volumes[0].Status != "deleted" {
+			return fmt.Errorf("Replaced volume %v still exists", volume.VolumeID)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckSolidFireVolumeSoftDeleted checks that volume is deleted but not purged
+func testAccCheckSolidFireVolumeSoftDeleted(volume *element.Volume) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		virConn := testAccProvider.Meta().(*element.Client)
+
+		res, err := virConn.ListDeletedVolumes(element.ListDeletedVolumesRequest{})
+		if err != nil {
+			return err
+		}
+
+		for _, v := range res.Volumes {
+			if v.VolumeID == volume.VolumeID {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("Volume %v is not in the deleted volumes", volume.VolumeID)
+	}
+}
+
+// testAccPurgeSolidFireVolume purges the deleted volume, so that its account can be destroyed
+func testAccPurgeSolidFireVolume(volume *element.Volume) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		virConn := testAccProvider.Meta().(*element.Client)
+
+		_, err := virConn.PurgeDeletedVolume(element.PurgeDeletedVolumeRequest{VolumeID: volume.VolumeID})
+		return err
+	}
+}
+
+// testAccCheckSolidFireVolumeRestored checks that resource n manages the previously deleted volume
+func testAccCheckSolidFireVolumeRestored(n string, volume *element.Volume) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID != strconv.Itoa(volume.VolumeID) {
+			return fmt.Errorf("Expected deleted volume %v to be restored, got volume %v", volume.VolumeID, rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckSolidFireVolumeAccess(volume *element.Volume, access string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if volume.Access != access {
+			return fmt.Errorf("Expected access %v, got %v", access, volume.Access)
+		}
+
+		return nil
+	}
+}
+
 const testAccCheckSolidFireVolumeConfig = `
 resource "solidfire_volume" "terraform-acceptance-test-1" {
 	name = "%s"
-	accountID = "${solidfire_account.terraform-acceptance-test-1.id}"
-	totalSize = "%s"
+	account_id = "${solidfire_account.terraform-acceptance-test-1.id}"
+	total_size = "%s"
 	enable512e = "%s"
-	minIOPS = "%s"
-	maxIOPS = "%s"
-	burstIOPS = "%s"
+	min_iops = "%s"
+	max_iops = "%s"
+	burst_iops = "%s"
+}
+resource "solidfire_account" "terraform-acceptance-test-1" {
+	username = "terraform-acceptance-test-volume"
+}
+`
+
+const testAccCheckSolidFireVolumeAccessConfig = `
+resource "solidfire_volume" "terraform-acceptance-test-1" {
+	name = "terraform-acceptance-test"
+	account_id = "${solidfire_account.terraform-acceptance-test-1.id}"
+	total_size = "1073741824"
+	enable512e = "true"
+	access = "%s"
+}
+resource "solidfire_account" "terraform-acceptance-test-1" {
+	username = "terraform-acceptance-test-volume"
+}
+`
+
+const testAccCheckSolidFireVolumeDeleteConfig = `
+resource "solidfire_volume" "terraform-acceptance-test-1" {
+	name = "terraform-acceptance-test"
+	account_id = "${solidfire_account.terraform-acceptance-test-1.id}"
+	total_size = "1073741824"
+	enable512e = "true"
+	%s
+}
+resource "solidfire_account" "terraform-acceptance-test-1" {
+	username = "terraform-acceptance-test-volume"
+}
+`
+
+const testAccCheckSolidFireVolumeTwinsConfig = `
+resource "solidfire_volume" "terraform-acceptance-test-1" {
+	name = "terraform-acceptance-test"
+	account_id = "${solidfire_account.terraform-acceptance-test-1.id}"
+	total_size = "1073741824"
+	enable512e = "true"
+	delete_behavior = "soft-delete"
+}
+resource "solidfire_volume" "terraform-acceptance-test-2" {
+	name = "terraform-acceptance-test"
+	account_id = "${solidfire_account.terraform-acceptance-test-1.id}"
+	total_size = "1073741824"
+	enable512e = "true"
+	delete_behavior = "soft-delete"
+}
+resource "solidfire_account" "terraform-acceptance-test-1" {
+	username = "terraform-acceptance-test-volume"
+}
+`
+
+const testAccCheckSolidFireVolumeAccountOnlyConfig = `
+resource "solidfire_account" "terraform-acceptance-test-1" {
+	username = "terraform-acceptance-test-volume"
+}
+`
+
+const testAccCheckSolidFireVolumeFifoConfig = `%s
+resource "solidfire_volume" "terraform-acceptance-test-1" {
+	name = "terraform-acceptance-test-fifo"
+	account_id = "${solidfire_account.terraform-acceptance-test-1.id}"
+	total_size = "1073741824"
+	enable512e = "true"
+	fifo_size = %d
+	min_fifo_size = %d
 }
 resource "solidfire_account" "terraform-acceptance-test-1" {
 	username = "terraform-acceptance-test-volume"
@@ -0,0 +1,74 @@
+package solidfire
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// validateDuration checks that a string argument is a Go duration such as "24h" or "90m"
+func validateDuration(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a duration such as \"24h\": %v", k, err))
+		return
+	}
+	if d <= 0 {
+		errors = append(errors, fmt.Errorf("%q must be positive, got %v", k, value))
+	}
+	return
+}
+
+var retentionPattern = regexp.MustCompile(`^(\d+):([0-5]\d):([0-5]\d)$`)
+
+// parseRetention parses a retention period in the HH:mm:ss format used by the Element API
+func parseRetention(retention string) (time.Duration, error) {
+	m := retentionPattern.FindStringSubmatch(retention)
+	if m == nil {
+		return 0, fmt.Errorf("%q is not a retention period in the HH:mm:ss format", retention)
+	}
+	hours, _ := strconv.Atoi(m[1])
+	minutes, _ := strconv.Atoi(m[2])
+	seconds, _ := strconv.Atoi(m[3])
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second, nil
+}
+
+// validateRetention checks that a string argument is a retention period such as "168:00:00"
+func validateRetention(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := parseRetention(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q: %v", k, err))
+	}
+	return
+}
+
+// validateRFC3339 checks that a string argument is a time such as "2019-01-02T15:04:05Z"
+func validateRFC3339(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := time.Parse(time.RFC3339, v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be an RFC 3339 time such as \"2019-01-02T15:04:05Z\": %v", k, err))
+	}
+	return
+}
+
+const (
+	chapSecretLengthMin = 12
+	chapSecretLengthMax = 16
+)
+
+// validateCHAPSecret checks that a string argument is a CHAP secret the cluster accepts: 12 to 16 printable ASCII
+// characters
+func validateCHAPSecret(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if len(value) < chapSecretLengthMin || len(value) > chapSecretLengthMax {
+		errors = append(errors, fmt.Errorf("%q must be %v to %v characters long, got %v", k, chapSecretLengthMin, chapSecretLengthMax, len(value)))
+		return
+	}
+	for _, c := range value {
+		if c < ' ' || c > '~' {
+			errors = append(errors, fmt.Errorf("%q must only contain printable ASCII characters", k))
+			return
+		}
+	}
+	return
+}